@@ -0,0 +1,915 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Metadatos de todas las tablas de un schema, agrupados por nombre de tabla.
+// Se leen con una consulta por tipo de objeto en lugar de una por tabla, lo
+// que evita miles de consultas en bases con muchas tablas.
+type schemaCatalog struct {
+	columns     map[string][]Column
+	foreignKeys map[string][]ForeignKey
+	indexes     map[string][]Index
+	triggers    map[string][]Trigger
+	checks      map[string][]CheckConstraint
+	// Nombre de la restricción DEFAULT por tabla y columna (SQL Server)
+	defaultConstraints map[string]map[string]string
+	// Descripción por tabla y columna; la columna vacía es la tabla
+	descriptions map[string]map[string]string
+	// Semilla, incremento y valor actual por tabla y columna identity
+	identities map[string]map[string]*IdentityInfo
+	// Metadatos opcionales que no se pudieron leer
+	errors []ObjectError
+}
+
+// Indica si el motor admite leer el catálogo de todo el schema de una vez.
+// Oracle, Sybase y SQLite se consultan tabla por tabla.
+func supportsBulkExtraction(dbType string) bool {
+	switch dbType {
+	case "sqlserver", "mysql", "postgres":
+		return true
+	}
+	return false
+}
+
+// Lee columnas, claves, índices, triggers y restricciones de todas las tablas
+// del schema. Las restricciones CHECK, los nombres de las restricciones
+// DEFAULT, las descripciones y los detalles de identidad son opcionales: si
+// no se pueden leer las tablas quedan sin ellos y el fallo se registra en
+// errors para que el esquema no parezca completo.
+func loadSchemaCatalog(db *sql.DB, config Config, schemaName string) (*schemaCatalog, error) {
+	dbType := config.DBType
+	catalog := &schemaCatalog{}
+	var err error
+
+	catalog.columns, err = loadCatalogColumns(db, dbType, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog.foreignKeys, err = loadCatalogForeignKeys(db, dbType, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog.indexes, err = loadCatalogIndexes(db, dbType, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog.triggers, err = loadCatalogTriggers(db, dbType, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	// MySQL anterior a 8.0.16 no tiene catálogo para las restricciones CHECK
+	catalog.checks, err = loadCatalogCheckConstraints(db, dbType, schemaName)
+	if err != nil {
+		catalog.addError(config.progress(), schemaName, "restricciones check", err)
+	}
+
+	catalog.defaultConstraints, err = loadCatalogDefaultConstraints(db, dbType, schemaName)
+	if err != nil {
+		catalog.addError(config.progress(), schemaName, "restricciones default", err)
+	}
+
+	catalog.descriptions, err = loadCatalogDescriptions(db, dbType, schemaName)
+	if err != nil {
+		catalog.addError(config.progress(), schemaName, "descripciones", err)
+	}
+
+	catalog.identities, err = loadCatalogIdentities(db, dbType, schemaName)
+	if err != nil {
+		catalog.addError(config.progress(), schemaName, "detalles de identidad", err)
+	}
+
+	return catalog, nil
+}
+
+// Avisa que no se pudo leer un metadato opcional del schema y lo registra
+func (c *schemaCatalog) addError(progress io.Writer, schemaName, what string, err error) {
+	fmt.Fprintf(progress, "  ⚠️  No se pudieron obtener %s del schema %s: %v\n", what, schemaName, err)
+	c.errors = append(c.errors, ObjectError{
+		Object: fmt.Sprintf("%s (%s)", schemaName, what),
+		Error:  fmt.Sprintf("no se pudieron obtener %s del schema %s: %v", what, schemaName, err),
+	})
+}
+
+// Arma una tabla con los metadatos del catálogo, sin consultar la base
+func (c *schemaCatalog) buildTable(config Config, tableSchema, tableName string) Table {
+	columns := filterColumns(c.columns[tableName], config.ColumnFilter, tableSchema, tableName)
+
+	if identities, ok := c.identities[tableName]; ok {
+		for i := range columns {
+			if identity, ok := identities[columns[i].ColumnName]; ok {
+				columns[i].IsIdentity = true
+				columns[i].Identity = identity
+			}
+		}
+	}
+
+	if names, ok := c.defaultConstraints[tableName]; ok {
+		for i := range columns {
+			columns[i].DefaultConstraint = names[columns[i].ColumnName]
+		}
+	}
+
+	// Las columnas de las restricciones que el catálogo no informa se buscan
+	// en la expresión, entre las columnas extraídas
+	var checks []CheckConstraint
+	for _, check := range c.checks[tableName] {
+		if len(check.Columns) == 0 {
+			check.Columns = checkConstraintColumns(check.Expression, columns)
+		}
+		checks = append(checks, check)
+	}
+
+	table := Table{
+		TableName:        tableName,
+		Schema:           tableSchema,
+		Columns:          columns,
+		ForeignKeys:      c.foreignKeys[tableName],
+		Indexes:          c.indexes[tableName],
+		Triggers:         c.triggers[tableName],
+		CheckConstraints: checks,
+	}
+
+	if descriptions, ok := c.descriptions[tableName]; ok {
+		table.Description = descriptions[""]
+		for i := range table.Columns {
+			table.Columns[i].Description = descriptions[table.Columns[i].ColumnName]
+		}
+	}
+
+	return table
+}
+
+// Ejecuta una consulta del catálogo con el schema como único parámetro
+func queryCatalog(db *sql.DB, dbType, query, schemaName string) (*sql.Rows, error) {
+	switch dbType {
+	case "sqlserver":
+		return db.Query(query, sql.Named("schema", schemaName))
+	case "mysql", "postgres":
+		return db.Query(query, schemaName)
+	default:
+		return nil, fmt.Errorf("tipo de base de datos no soportado: %s", dbType)
+	}
+}
+
+func loadCatalogColumns(db *sql.DB, dbType, schemaName string) (map[string][]Column, error) {
+	rows, err := queryCatalog(db, dbType, getCatalogColumnsQuery(dbType), schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar columnas: %v", err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string][]Column)
+	for rows.Next() {
+		var tableName string
+
+		col, err := scanColumn(rows, dbType, &tableName)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear columna: %v", err)
+		}
+		columns[tableName] = append(columns[tableName], col)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre columnas: %v", err)
+	}
+
+	return columns, nil
+}
+
+func loadCatalogForeignKeys(db *sql.DB, dbType, schemaName string) (map[string][]ForeignKey, error) {
+	rows, err := queryCatalog(db, dbType, getCatalogForeignKeysQuery(dbType), schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar claves foráneas: %v", err)
+	}
+	defer rows.Close()
+
+	foreignKeys := make(map[string][]ForeignKey)
+	for rows.Next() {
+		var tableName, constraintName, columnName, refSchema, refTable, refColumn string
+		var onDelete, onUpdate sql.NullString
+
+		err := rows.Scan(&tableName, &constraintName, &columnName, &refSchema, &refTable, &refColumn, &onDelete, &onUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear clave foránea: %v", err)
+		}
+
+		foreignKeys[tableName] = appendForeignKeyColumn(foreignKeys[tableName], constraintName, columnName,
+			refSchema, refTable, refColumn, onDelete.String, onUpdate.String)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre claves foráneas: %v", err)
+	}
+
+	return foreignKeys, nil
+}
+
+func loadCatalogIndexes(db *sql.DB, dbType, schemaName string) (map[string][]Index, error) {
+	rows, err := queryCatalog(db, dbType, getCatalogIndexesQuery(dbType), schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar índices: %v", err)
+	}
+	defer rows.Close()
+
+	indexes := make(map[string][]Index)
+	for rows.Next() {
+		var tableName, indexName, columnName, sortOrder, indexType, filterPredicate string
+		var isIncluded, isUnique, isPrimaryKey, isUniqueConstraint, isClustered int
+
+		err := rows.Scan(
+			&tableName,
+			&indexName,
+			&columnName,
+			&sortOrder,
+			&isIncluded,
+			&isUnique,
+			&isPrimaryKey,
+			&isUniqueConstraint,
+			&isClustered,
+			&indexType,
+			&filterPredicate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear índice: %v", err)
+		}
+
+		indexes[tableName] = appendIndexColumn(indexes[tableName], Index{
+			IndexName:          indexName,
+			IsUnique:           isUnique == 1,
+			IsPrimaryKey:       isPrimaryKey == 1,
+			IsUniqueConstraint: isUniqueConstraint == 1,
+			IsClustered:        isClustered == 1,
+			IndexType:          indexType,
+			FilterPredicate:    filterPredicate,
+		}, columnName, sortOrder, isIncluded == 1)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre índices: %v", err)
+	}
+
+	return indexes, nil
+}
+
+func loadCatalogTriggers(db *sql.DB, dbType, schemaName string) (map[string][]Trigger, error) {
+	rows, err := queryCatalog(db, dbType, getCatalogTriggersQuery(dbType), schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar triggers: %v", err)
+	}
+	defer rows.Close()
+
+	triggers := make(map[string][]Trigger)
+	for rows.Next() {
+		var tableName, name, timing, events string
+		var enabled int
+		var definition sql.NullString
+
+		err := rows.Scan(&tableName, &name, &timing, &events, &enabled, &definition)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear trigger: %v", err)
+		}
+
+		triggers[tableName] = appendTriggerEvents(triggers[tableName], Trigger{
+			TriggerName: name,
+			Timing:      timing,
+			Enabled:     enabled == 1,
+			Definition:  strings.TrimSpace(definition.String),
+		}, events)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre triggers: %v", err)
+	}
+
+	return triggers, nil
+}
+
+// Las columnas de las restricciones se completan al armar cada tabla cuando
+// el catálogo no las informa
+func loadCatalogCheckConstraints(db *sql.DB, dbType, schemaName string) (map[string][]CheckConstraint, error) {
+	rows, err := queryCatalog(db, dbType, getCatalogCheckConstraintsQuery(dbType), schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar restricciones check: %v", err)
+	}
+	defer rows.Close()
+
+	checks := make(map[string][]CheckConstraint)
+	for rows.Next() {
+		var tableName, name string
+		var expression, referenced sql.NullString
+
+		err := rows.Scan(&tableName, &name, &expression, &referenced)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear restricción check: %v", err)
+		}
+
+		check := CheckConstraint{
+			ConstraintName: name,
+			Expression:     normalizeCheckExpression(expression.String),
+		}
+		if referenced.String != "" {
+			check.Columns = strings.Split(referenced.String, ",")
+		}
+
+		checks[tableName] = append(checks[tableName], check)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre restricciones check: %v", err)
+	}
+
+	return checks, nil
+}
+
+// Solo SQL Server nombra las restricciones DEFAULT en un catálogo aparte
+func loadCatalogDefaultConstraints(db *sql.DB, dbType, schemaName string) (map[string]map[string]string, error) {
+	if dbType != "sqlserver" {
+		return nil, nil
+	}
+
+	rows, err := queryCatalog(db, dbType, `
+		SELECT
+			OBJECT_NAME(dc.parent_object_id) AS TABLE_NAME,
+			COL_NAME(dc.parent_object_id, dc.parent_column_id) AS COLUMN_NAME,
+			dc.name AS CONSTRAINT_NAME
+		FROM sys.default_constraints dc
+		WHERE SCHEMA_NAME(dc.schema_id) = @schema
+	`, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar restricciones default: %v", err)
+	}
+	defer rows.Close()
+
+	names := make(map[string]map[string]string)
+	for rows.Next() {
+		var tableName, columnName, constraintName string
+		if err := rows.Scan(&tableName, &columnName, &constraintName); err != nil {
+			return nil, fmt.Errorf("error al escanear restricción default: %v", err)
+		}
+		if names[tableName] == nil {
+			names[tableName] = make(map[string]string)
+		}
+		names[tableName][columnName] = constraintName
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre restricciones default: %v", err)
+	}
+
+	return names, nil
+}
+
+func loadCatalogIdentities(db *sql.DB, dbType, schemaName string) (map[string]map[string]*IdentityInfo, error) {
+	rows, err := queryCatalog(db, dbType, getCatalogIdentitiesQuery(dbType), schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar identidad: %v", err)
+	}
+	defer rows.Close()
+
+	identities := make(map[string]map[string]*IdentityInfo)
+	for rows.Next() {
+		var tableName, columnName, generation string
+		var seed, increment, currentValue sql.NullInt64
+		var sequence sql.NullString
+
+		err := rows.Scan(&tableName, &columnName, &generation, &seed, &increment, &currentValue, &sequence)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear identidad: %v", err)
+		}
+
+		if identities[tableName] == nil {
+			identities[tableName] = make(map[string]*IdentityInfo)
+		}
+		identities[tableName][columnName] = newIdentityInfo(generation, seed, increment, currentValue, sequence)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre identidad: %v", err)
+	}
+
+	return identities, nil
+}
+
+// Igual que getIdentityQuery pero para todo el schema, con la tabla primero
+func getCatalogIdentitiesQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				t.name AS TABLE_NAME,
+				ic.name AS COLUMN_NAME,
+				'ALWAYS' AS GENERATION,
+				CAST(ic.seed_value AS bigint) AS SEED,
+				CAST(ic.increment_value AS bigint) AS INCREMENT,
+				CAST(ic.last_value AS bigint) AS CURRENT_VALUE,
+				NULL AS SEQUENCE_NAME
+			FROM sys.identity_columns ic
+			JOIN sys.tables t ON t.object_id = ic.object_id
+			WHERE SCHEMA_NAME(t.schema_id) = @schema
+		`
+	case "mysql":
+		return `
+			SELECT
+				TABLE_NAME,
+				COLUMN_NAME,
+				'BY DEFAULT' AS GENERATION,
+				@@auto_increment_offset AS SEED,
+				@@auto_increment_increment AS INCREMENT,
+				NULL AS CURRENT_VALUE,
+				NULL AS SEQUENCE_NAME
+			FROM INFORMATION_SCHEMA.COLUMNS
+			WHERE TABLE_SCHEMA = ?
+			AND EXTRA LIKE '%auto_increment%'
+		`
+	case "postgres":
+		// Las dependencias auto (serial) e internas (identity) entre la
+		// secuencia y la columna son las que resuelve pg_get_serial_sequence
+		return `
+			SELECT
+				c.relname,
+				a.attname,
+				CASE a.attidentity WHEN 'a' THEN 'ALWAYS' WHEN 'd' THEN 'BY DEFAULT' ELSE 'SEQUENCE' END,
+				s.start_value,
+				s.increment_by,
+				s.last_value,
+				s.schemaname || '.' || s.sequencename
+			FROM pg_depend d
+			JOIN pg_class seq ON seq.oid = d.objid AND seq.relkind = 'S'
+			JOIN pg_namespace sn ON sn.oid = seq.relnamespace
+			JOIN pg_sequences s ON s.schemaname = sn.nspname AND s.sequencename = seq.relname
+			JOIN pg_class c ON c.oid = d.refobjid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = d.refobjsubid
+			WHERE d.classid = 'pg_class'::regclass
+			AND d.refclassid = 'pg_class'::regclass
+			AND d.deptype IN ('a', 'i')
+			AND n.nspname = $1
+		`
+	default:
+		return ""
+	}
+}
+
+func loadCatalogDescriptions(db *sql.DB, dbType, schemaName string) (map[string]map[string]string, error) {
+	query := getCatalogDescriptionsQuery(dbType)
+	var rows *sql.Rows
+	var err error
+
+	// La consulta de MySQL une tablas y columnas, cada una con su parámetro
+	if dbType == "mysql" {
+		rows, err = db.Query(query, schemaName, schemaName)
+	} else {
+		rows, err = queryCatalog(db, dbType, query, schemaName)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar descripciones: %v", err)
+	}
+	defer rows.Close()
+
+	descriptions := make(map[string]map[string]string)
+	for rows.Next() {
+		var tableName string
+		var columnName, description sql.NullString
+
+		if err := rows.Scan(&tableName, &columnName, &description); err != nil {
+			return nil, fmt.Errorf("error al escanear descripción: %v", err)
+		}
+		if descriptions[tableName] == nil {
+			descriptions[tableName] = make(map[string]string)
+		}
+		descriptions[tableName][columnName.String] = strings.TrimSpace(description.String)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre descripciones: %v", err)
+	}
+
+	return descriptions, nil
+}
+
+// Las consultas del catálogo devuelven las mismas columnas que las consultas
+// por tabla (getColumnsQuery, getForeignKeysQuery...) precedidas por el nombre
+// de la tabla, y se ordenan primero por tabla
+
+func getCatalogColumnsQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				c.TABLE_NAME,
+				c.COLUMN_NAME,
+				c.DATA_TYPE,
+				c.IS_NULLABLE,
+				c.CHARACTER_MAXIMUM_LENGTH,
+				c.NUMERIC_PRECISION,
+				c.NUMERIC_SCALE,
+				c.DATETIME_PRECISION,
+				CASE WHEN pk.COLUMN_NAME IS NOT NULL THEN 1 ELSE 0 END AS IS_PRIMARY_KEY,
+				COLUMNPROPERTY(OBJECT_ID(c.TABLE_SCHEMA + '.' + c.TABLE_NAME), c.COLUMN_NAME, 'IsIdentity') AS IS_IDENTITY,
+				COALESCE(c.COLUMN_DEFAULT, '') AS COLUMN_DEFAULT
+			FROM INFORMATION_SCHEMA.COLUMNS c
+			LEFT JOIN (
+				SELECT
+					ku.TABLE_SCHEMA,
+					ku.TABLE_NAME,
+					ku.COLUMN_NAME
+				FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+				INNER JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE ku
+					ON tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+					AND tc.CONSTRAINT_NAME = ku.CONSTRAINT_NAME
+			) pk ON c.TABLE_SCHEMA = pk.TABLE_SCHEMA
+				AND c.TABLE_NAME = pk.TABLE_NAME
+				AND c.COLUMN_NAME = pk.COLUMN_NAME
+			WHERE c.TABLE_SCHEMA = @schema
+			ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION
+		`
+	case "mysql":
+		return `
+			SELECT
+				TABLE_NAME,
+				COLUMN_NAME,
+				DATA_TYPE,
+				IS_NULLABLE,
+				CHARACTER_MAXIMUM_LENGTH,
+				NUMERIC_PRECISION,
+				NUMERIC_SCALE,
+				DATETIME_PRECISION,
+				CASE WHEN COLUMN_KEY = 'PRI' THEN 1 ELSE 0 END AS IS_PRIMARY_KEY,
+				CASE WHEN EXTRA LIKE '%auto_increment%' THEN 1 ELSE 0 END AS IS_IDENTITY,
+				COALESCE(COLUMN_DEFAULT, '') AS COLUMN_DEFAULT,
+				CASE WHEN COLUMN_TYPE LIKE '%unsigned%' THEN 1 ELSE 0 END AS IS_UNSIGNED
+			FROM INFORMATION_SCHEMA.COLUMNS
+			WHERE TABLE_SCHEMA = ?
+			ORDER BY TABLE_NAME, ORDINAL_POSITION
+		`
+	case "postgres":
+		return `
+			SELECT
+				c.table_name,
+				c.column_name,
+				-- Arreglos y tipos de usuario, como en getColumnsQuery
+				CASE
+					WHEN c.data_type IN ('ARRAY', 'USER-DEFINED')
+					THEN format_type(format('%I.%I', c.udt_schema, c.udt_name)::regtype, NULL)
+					ELSE c.data_type
+				END AS data_type,
+				c.is_nullable,
+				c.character_maximum_length,
+				c.numeric_precision,
+				c.numeric_scale,
+				c.datetime_precision,
+				CASE
+					WHEN EXISTS (
+						SELECT 1
+						FROM information_schema.key_column_usage k
+						JOIN information_schema.table_constraints tc
+						ON k.constraint_name = tc.constraint_name
+						AND k.table_schema = tc.table_schema
+						WHERE k.table_schema = c.table_schema
+							AND k.table_name = c.table_name
+							AND k.column_name = c.column_name
+							AND tc.constraint_type = 'PRIMARY KEY')
+					THEN 1
+					ELSE 0
+				END AS is_primary_key,
+				CASE
+					WHEN c.column_default LIKE 'nextval%' OR c.is_identity = 'YES' THEN 1
+					ELSE 0
+				END AS is_identity,
+				COALESCE(c.column_default, '') AS column_default
+			FROM information_schema.columns c
+			WHERE c.table_schema = $1
+			ORDER BY c.table_name, c.ordinal_position
+		`
+	default:
+		return ""
+	}
+}
+
+func getCatalogForeignKeysQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				pt.name AS TABLE_NAME,
+				fk.name AS CONSTRAINT_NAME,
+				pc.name AS COLUMN_NAME,
+				SCHEMA_NAME(rt.schema_id) AS REFERENCED_SCHEMA,
+				rt.name AS REFERENCED_TABLE,
+				rc.name AS REFERENCED_COLUMN,
+				REPLACE(fk.delete_referential_action_desc, '_', ' ') AS ON_DELETE,
+				REPLACE(fk.update_referential_action_desc, '_', ' ') AS ON_UPDATE
+			FROM sys.foreign_keys fk
+			INNER JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+			INNER JOIN sys.tables pt ON pt.object_id = fk.parent_object_id
+			INNER JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+			INNER JOIN sys.tables rt ON rt.object_id = fk.referenced_object_id
+			INNER JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+			WHERE SCHEMA_NAME(pt.schema_id) = @schema
+			ORDER BY pt.name, fk.name, fkc.constraint_column_id
+		`
+	case "mysql":
+		return `
+			SELECT
+				kcu.TABLE_NAME,
+				kcu.CONSTRAINT_NAME,
+				kcu.COLUMN_NAME,
+				kcu.REFERENCED_TABLE_SCHEMA,
+				kcu.REFERENCED_TABLE_NAME,
+				kcu.REFERENCED_COLUMN_NAME,
+				rc.DELETE_RULE,
+				rc.UPDATE_RULE
+			FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc
+			INNER JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+				ON kcu.CONSTRAINT_SCHEMA = rc.CONSTRAINT_SCHEMA
+				AND kcu.CONSTRAINT_NAME = rc.CONSTRAINT_NAME
+				AND kcu.TABLE_NAME = rc.TABLE_NAME
+			WHERE kcu.TABLE_SCHEMA = ?
+			ORDER BY kcu.TABLE_NAME, kcu.CONSTRAINT_NAME, kcu.ORDINAL_POSITION
+		`
+	case "postgres":
+		return `
+			SELECT
+				cls.relname AS table_name,
+				con.conname AS constraint_name,
+				att.attname AS column_name,
+				ref_nsp.nspname AS referenced_schema,
+				ref_cls.relname AS referenced_table,
+				ref_att.attname AS referenced_column,
+				CASE con.confdeltype
+					WHEN 'a' THEN 'NO ACTION'
+					WHEN 'r' THEN 'RESTRICT'
+					WHEN 'c' THEN 'CASCADE'
+					WHEN 'n' THEN 'SET NULL'
+					WHEN 'd' THEN 'SET DEFAULT'
+				END AS on_delete,
+				CASE con.confupdtype
+					WHEN 'a' THEN 'NO ACTION'
+					WHEN 'r' THEN 'RESTRICT'
+					WHEN 'c' THEN 'CASCADE'
+					WHEN 'n' THEN 'SET NULL'
+					WHEN 'd' THEN 'SET DEFAULT'
+				END AS on_update
+			FROM pg_constraint con
+			JOIN pg_class cls ON cls.oid = con.conrelid
+			JOIN pg_namespace nsp ON nsp.oid = cls.relnamespace
+			JOIN pg_class ref_cls ON ref_cls.oid = con.confrelid
+			JOIN pg_namespace ref_nsp ON ref_nsp.oid = ref_cls.relnamespace
+			CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, ref_attnum, ord)
+			JOIN pg_attribute att ON att.attrelid = con.conrelid AND att.attnum = k.attnum
+			JOIN pg_attribute ref_att ON ref_att.attrelid = con.confrelid AND ref_att.attnum = k.ref_attnum
+			WHERE con.contype = 'f'
+			  AND nsp.nspname = $1
+			ORDER BY cls.relname, con.conname, k.ord
+		`
+	default:
+		return ""
+	}
+}
+
+func getCatalogIndexesQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				t.name AS TABLE_NAME,
+				i.name AS INDEX_NAME,
+				c.name AS COLUMN_NAME,
+				CASE WHEN ic.is_descending_key = 1 THEN 'DESC' ELSE 'ASC' END AS SORT_ORDER,
+				CASE WHEN ic.is_included_column = 1 THEN 1 ELSE 0 END AS IS_INCLUDED,
+				CASE WHEN i.is_unique = 1 THEN 1 ELSE 0 END AS IS_UNIQUE,
+				CASE WHEN i.is_primary_key = 1 THEN 1 ELSE 0 END AS IS_PRIMARY_KEY,
+				CASE WHEN i.is_unique_constraint = 1 THEN 1 ELSE 0 END AS IS_UNIQUE_CONSTRAINT,
+				CASE WHEN i.type IN (1, 5) THEN 1 ELSE 0 END AS IS_CLUSTERED,
+				i.type_desc AS INDEX_TYPE,
+				COALESCE(i.filter_definition, '') AS FILTER_PREDICATE
+			FROM sys.indexes i
+			INNER JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+			INNER JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+			INNER JOIN sys.tables t ON t.object_id = i.object_id
+			WHERE SCHEMA_NAME(t.schema_id) = @schema
+				AND i.type > 0  -- Excluir el heap
+			ORDER BY t.name, i.name, ic.is_included_column, ic.key_ordinal, ic.index_column_id
+		`
+	case "mysql":
+		return `
+			SELECT
+				s.TABLE_NAME,
+				s.INDEX_NAME,
+				COALESCE(s.COLUMN_NAME, '') AS COLUMN_NAME,
+				CASE WHEN s.COLLATION = 'D' THEN 'DESC' ELSE 'ASC' END AS SORT_ORDER,
+				0 AS IS_INCLUDED,
+				CASE WHEN s.NON_UNIQUE = 0 THEN 1 ELSE 0 END AS IS_UNIQUE,
+				CASE WHEN s.INDEX_NAME = 'PRIMARY' THEN 1 ELSE 0 END AS IS_PRIMARY_KEY,
+				CASE WHEN tc.CONSTRAINT_TYPE = 'UNIQUE' THEN 1 ELSE 0 END AS IS_UNIQUE_CONSTRAINT,
+				CASE WHEN s.INDEX_NAME = 'PRIMARY' AND t.ENGINE = 'InnoDB' THEN 1 ELSE 0 END AS IS_CLUSTERED,
+				s.INDEX_TYPE,
+				'' AS FILTER_PREDICATE
+			FROM INFORMATION_SCHEMA.STATISTICS s
+			INNER JOIN INFORMATION_SCHEMA.TABLES t
+				ON t.TABLE_SCHEMA = s.TABLE_SCHEMA
+				AND t.TABLE_NAME = s.TABLE_NAME
+			LEFT JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+				ON tc.TABLE_SCHEMA = s.TABLE_SCHEMA
+				AND tc.TABLE_NAME = s.TABLE_NAME
+				AND tc.CONSTRAINT_NAME = s.INDEX_NAME
+				AND tc.CONSTRAINT_TYPE = 'UNIQUE'
+			WHERE s.TABLE_SCHEMA = ?
+			ORDER BY s.TABLE_NAME, s.INDEX_NAME, s.SEQ_IN_INDEX
+		`
+	case "postgres":
+		return `
+			SELECT
+				tc.relname AS table_name,
+				ic.relname AS index_name,
+				COALESCE(a.attname, pg_get_indexdef(i.indexrelid, k.ord::int, true)) AS column_name,
+				CASE WHEN COALESCE(i.indoption[(k.ord - 1)::int], 0) & 1 = 1 THEN 'DESC' ELSE 'ASC' END AS sort_order,
+				CASE WHEN k.ord > i.indnkeyatts THEN 1 ELSE 0 END AS is_included,
+				CASE WHEN i.indisunique THEN 1 ELSE 0 END AS is_unique,
+				CASE WHEN i.indisprimary THEN 1 ELSE 0 END AS is_primary_key,
+				CASE WHEN con.contype = 'u' THEN 1 ELSE 0 END AS is_unique_constraint,
+				CASE WHEN i.indisclustered THEN 1 ELSE 0 END AS is_clustered,
+				am.amname AS index_type,
+				COALESCE(pg_get_expr(i.indpred, i.indrelid), '') AS filter_predicate
+			FROM pg_index i
+			JOIN pg_class ic ON ic.oid = i.indexrelid
+			JOIN pg_class tc ON tc.oid = i.indrelid
+			JOIN pg_namespace n ON n.oid = tc.relnamespace
+			JOIN pg_am am ON am.oid = ic.relam
+			LEFT JOIN pg_constraint con ON con.conindid = i.indexrelid AND con.contype = 'u'
+			CROSS JOIN LATERAL unnest(i.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+			LEFT JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum AND k.attnum <> 0
+			WHERE n.nspname = $1
+			ORDER BY tc.relname, ic.relname, k.ord
+		`
+	default:
+		return ""
+	}
+}
+
+func getCatalogTriggersQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				t.name AS TABLE_NAME,
+				tr.name AS TRIGGER_NAME,
+				CASE WHEN tr.is_instead_of_trigger = 1 THEN 'INSTEAD OF' ELSE 'AFTER' END AS TIMING,
+				te.type_desc AS EVENT,
+				CASE WHEN tr.is_disabled = 1 THEN 0 ELSE 1 END AS IS_ENABLED,
+				m.definition AS DEFINITION
+			FROM sys.triggers tr
+			JOIN sys.tables t ON t.object_id = tr.parent_id
+			JOIN sys.trigger_events te ON te.object_id = tr.object_id
+			LEFT JOIN sys.sql_modules m ON m.object_id = tr.object_id
+			WHERE SCHEMA_NAME(t.schema_id) = @schema
+			ORDER BY t.name, tr.name, te.type
+		`
+	case "mysql":
+		return `
+			SELECT
+				EVENT_OBJECT_TABLE,
+				TRIGGER_NAME,
+				ACTION_TIMING,
+				EVENT_MANIPULATION,
+				1 AS IS_ENABLED,
+				ACTION_STATEMENT
+			FROM INFORMATION_SCHEMA.TRIGGERS
+			WHERE EVENT_OBJECT_SCHEMA = ?
+			ORDER BY EVENT_OBJECT_TABLE, ACTION_ORDER, TRIGGER_NAME
+		`
+	case "postgres":
+		return `
+			SELECT
+				c.relname,
+				t.tgname,
+				CASE
+					WHEN t.tgtype & 2 = 2 THEN 'BEFORE'
+					WHEN t.tgtype & 64 = 64 THEN 'INSTEAD OF'
+					ELSE 'AFTER'
+				END,
+				concat_ws(',',
+					CASE WHEN t.tgtype & 4 = 4 THEN 'INSERT' END,
+					CASE WHEN t.tgtype & 16 = 16 THEN 'UPDATE' END,
+					CASE WHEN t.tgtype & 8 = 8 THEN 'DELETE' END,
+					CASE WHEN t.tgtype & 32 = 32 THEN 'TRUNCATE' END),
+				CASE WHEN t.tgenabled = 'D' THEN 0 ELSE 1 END,
+				pg_get_triggerdef(t.oid, true)
+			FROM pg_trigger t
+			JOIN pg_class c ON c.oid = t.tgrelid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = $1
+			AND NOT t.tgisinternal
+			ORDER BY c.relname, t.tgname
+		`
+	default:
+		return ""
+	}
+}
+
+func getCatalogCheckConstraintsQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				OBJECT_NAME(cc.parent_object_id) AS TABLE_NAME,
+				cc.name AS CONSTRAINT_NAME,
+				cc.definition AS EXPRESSION,
+				COALESCE(COL_NAME(cc.parent_object_id, cc.parent_column_id), '') AS COLUMNS
+			FROM sys.check_constraints cc
+			WHERE SCHEMA_NAME(cc.schema_id) = @schema
+			ORDER BY TABLE_NAME, cc.name
+		`
+	case "mysql":
+		return `
+			SELECT
+				tc.TABLE_NAME,
+				cc.CONSTRAINT_NAME,
+				cc.CHECK_CLAUSE,
+				'' AS COLUMNS
+			FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+			JOIN INFORMATION_SCHEMA.CHECK_CONSTRAINTS cc
+				ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+				AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+			WHERE tc.TABLE_SCHEMA = ?
+			AND tc.CONSTRAINT_TYPE = 'CHECK'
+			ORDER BY tc.TABLE_NAME, cc.CONSTRAINT_NAME
+		`
+	case "postgres":
+		return `
+			SELECT
+				cl.relname,
+				c.conname,
+				pg_get_constraintdef(c.oid, true),
+				COALESCE((
+					SELECT string_agg(a.attname, ',' ORDER BY a.attnum)
+					FROM pg_attribute a
+					WHERE a.attrelid = c.conrelid
+					AND a.attnum = ANY(c.conkey)
+				), '')
+			FROM pg_constraint c
+			JOIN pg_class cl ON cl.oid = c.conrelid
+			JOIN pg_namespace n ON n.oid = cl.relnamespace
+			WHERE n.nspname = $1
+			AND c.contype = 'c'
+			ORDER BY cl.relname, c.conname
+		`
+	default:
+		return ""
+	}
+}
+
+func getCatalogDescriptionsQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				t.name AS TABLE_NAME,
+				COALESCE(COL_NAME(ep.major_id, NULLIF(ep.minor_id, 0)), '') AS COLUMN_NAME,
+				CAST(ep.value AS nvarchar(4000)) AS DESCRIPTION
+			FROM sys.extended_properties ep
+			JOIN sys.tables t ON t.object_id = ep.major_id
+			WHERE ep.class = 1
+			AND ep.name = 'MS_Description'
+			AND SCHEMA_NAME(t.schema_id) = @schema
+		`
+	case "mysql":
+		return `
+			SELECT TABLE_NAME, '' AS COLUMN_NAME, TABLE_COMMENT
+			FROM INFORMATION_SCHEMA.TABLES
+			WHERE TABLE_SCHEMA = ? AND TABLE_COMMENT <> ''
+			UNION ALL
+			SELECT TABLE_NAME, COLUMN_NAME, COLUMN_COMMENT
+			FROM INFORMATION_SCHEMA.COLUMNS
+			WHERE TABLE_SCHEMA = ? AND COLUMN_COMMENT <> ''
+		`
+	case "postgres":
+		return `
+			SELECT
+				c.relname,
+				COALESCE(a.attname, ''),
+				d.description
+			FROM pg_description d
+			JOIN pg_class c ON c.oid = d.objoid
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			LEFT JOIN pg_attribute a
+				ON a.attrelid = d.objoid
+				AND a.attnum = d.objsubid
+			WHERE d.classoid = 'pg_class'::regclass
+			AND n.nspname = $1
+			AND (d.objsubid = 0 OR NOT a.attisdropped)
+		`
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testSchemaCatalog() *schemaCatalog {
+	return &schemaCatalog{
+		columns: map[string][]Column{
+			"pedidos": {
+				{ColumnName: "id", DataType: "int", IsNullable: "NO"},
+				{ColumnName: "total", DataType: "decimal", IsNullable: "NO"},
+				{ColumnName: "notas", DataType: "nvarchar", IsNullable: "YES"},
+			},
+		},
+		foreignKeys: map[string][]ForeignKey{
+			"pedidos": {{ConstraintName: "fk_pedidos_clientes", Columns: []string{"cliente_id"}, ReferencedTable: "clientes"}},
+		},
+		indexes: map[string][]Index{
+			"pedidos": {{IndexName: "pk_pedidos", IsPrimaryKey: true, Columns: []IndexColumn{{ColumnName: "id", Order: "ASC"}}}},
+		},
+		checks: map[string][]CheckConstraint{
+			"pedidos": {{ConstraintName: "ck_pedidos_total", Expression: "([total]>=(0))"}},
+		},
+		defaultConstraints: map[string]map[string]string{
+			"pedidos": {"total": "df_pedidos_total"},
+		},
+		descriptions: map[string]map[string]string{
+			"pedidos": {"": "Pedidos de clientes", "total": "Importe con impuestos"},
+		},
+		identities: map[string]map[string]*IdentityInfo{
+			"pedidos": {"id": {Generation: "ALWAYS", Seed: 1, Increment: 1}},
+		},
+	}
+}
+
+func TestSchemaCatalogBuildTable(t *testing.T) {
+	table := testSchemaCatalog().buildTable(Config{}, "dbo", "pedidos")
+
+	if table.TableName != "pedidos" || table.Schema != "dbo" || table.Description != "Pedidos de clientes" {
+		t.Errorf("tabla = %s.%s (%q)", table.Schema, table.TableName, table.Description)
+	}
+	if len(table.Columns) != 3 {
+		t.Fatalf("columnas = %+v, se esperaban 3", table.Columns)
+	}
+
+	id, total := table.Columns[0], table.Columns[1]
+	if !id.IsIdentity || id.Identity == nil || id.Identity.Seed != 1 {
+		t.Errorf("id: isIdentity=%v identity=%+v", id.IsIdentity, id.Identity)
+	}
+	if total.IsIdentity || total.DefaultConstraint != "df_pedidos_total" || total.Description != "Importe con impuestos" {
+		t.Errorf("total: isIdentity=%v defaultConstraint=%q description=%q", total.IsIdentity, total.DefaultConstraint, total.Description)
+	}
+
+	// Las columnas del check que el catálogo no informa salen de la expresión
+	wantChecks := []CheckConstraint{{ConstraintName: "ck_pedidos_total", Expression: "([total]>=(0))", Columns: []string{"total"}}}
+	if !reflect.DeepEqual(table.CheckConstraints, wantChecks) {
+		t.Errorf("checks = %+v, se esperaba %+v", table.CheckConstraints, wantChecks)
+	}
+	if len(table.ForeignKeys) != 1 || len(table.Indexes) != 1 {
+		t.Errorf("claves foráneas = %+v, índices = %+v", table.ForeignKeys, table.Indexes)
+	}
+}
+
+func TestSchemaCatalogBuildTableColumnFilter(t *testing.T) {
+	filter, err := newNameFilter("", "pedidos.notas")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := testSchemaCatalog().buildTable(Config{ColumnFilter: filter}, "dbo", "pedidos")
+
+	var names []string
+	for _, col := range table.Columns {
+		names = append(names, col.ColumnName)
+	}
+	if want := []string{"id", "total"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("columnas = %v, se esperaba %v", names, want)
+	}
+}
+
+func TestSchemaCatalogBuildTableMissing(t *testing.T) {
+	// Una tabla sin metadatos opcionales en el catálogo queda sin ellos
+	catalog := &schemaCatalog{columns: map[string][]Column{"notas": {{ColumnName: "texto", DataType: "text"}}}}
+	table := catalog.buildTable(Config{}, "public", "notas")
+
+	if len(table.Columns) != 1 || table.Columns[0].IsIdentity || table.Description != "" ||
+		table.ForeignKeys != nil || table.CheckConstraints != nil {
+		t.Errorf("tabla = %+v", table)
+	}
+}
+
+func TestSchemaCatalogAddError(t *testing.T) {
+	var progress bytes.Buffer
+	catalog := &schemaCatalog{}
+	catalog.addError(&progress, "dbo", "descripciones", errors.New("permiso denegado"))
+
+	want := []ObjectError{{Object: "dbo (descripciones)", Error: "no se pudieron obtener descripciones del schema dbo: permiso denegado"}}
+	if !reflect.DeepEqual(catalog.errors, want) {
+		t.Errorf("errors = %+v, se esperaba %+v", catalog.errors, want)
+	}
+	if !strings.Contains(progress.String(), "permiso denegado") {
+		t.Errorf("aviso = %q", progress.String())
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// Códigos de salida del modo check
+const (
+	driftNone       = 0
+	driftCompatible = 1
+	driftBreaking   = 2
+	driftError      = 3
+)
+
+// Diferencias que el modo check no tiene en cuenta. Los patrones son los
+// mismos que los de -include/-exclude: glob sin distinguir mayúsculas o
+// expresiones regulares entre barras. Las tablas se comparan como
+// tabla o schema.tabla y las columnas como columna, tabla.columna o
+// schema.tabla.columna. Los atributos son los nombres del diff (defaultValue,
+// isNullable, primaryKey, definition...) o una categoría completa: indexes,
+// foreignKeys, checkConstraints, triggers, views, routines o sequences.
+type DriftIgnore struct {
+	Tables     []string `json:"tables"`
+	Columns    []string `json:"columns"`
+	Attributes []string `json:"attributes"`
+}
+
+// Lista de exclusiones ya compilada; las tablas y columnas que no pasan el
+// filtro se ignoran
+type driftIgnoreRules struct {
+	Tables     NameFilter
+	Columns    NameFilter
+	Attributes map[string]bool
+}
+
+// Compila los patrones de la lista de exclusiones; un patrón no válido es un
+// error en lugar de no coincidir nunca
+func compileDriftIgnore(ignore DriftIgnore) (driftIgnoreRules, error) {
+	rules := driftIgnoreRules{Attributes: make(map[string]bool)}
+	var err error
+
+	rules.Tables.Exclude, err = compilePatterns(ignore.Tables)
+	if err != nil {
+		return rules, fmt.Errorf("error en tables: %v", err)
+	}
+	rules.Columns.Exclude, err = compilePatterns(ignore.Columns)
+	if err != nil {
+		return rules, fmt.Errorf("error en columns: %v", err)
+	}
+	for _, attribute := range ignore.Attributes {
+		rules.Attributes[strings.ToLower(attribute)] = true
+	}
+
+	return rules, nil
+}
+
+// Resultado del modo check en formato JSON; la clasificación de cada cambio
+// va dentro del diff
+type DriftReport struct {
+	Drift string      `json:"drift"`
+	Diff  *SchemaDiff `json:"diff"`
+}
+
+// Subcomando check: extrae el esquema en vivo y lo compara con una línea base.
+// Devuelve 0 sin desvíos, 1 con desvíos compatibles, 2 con desvíos
+// incompatibles y 3 ante un error.
+func runCheckCommand(args []string) int {
+	// ContinueOnError: con ExitOnError un flag inválido saldría con 2, que
+	// aquí significa desvío incompatible
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	ignoreFile := flags.String("ignore", "", "Archivo JSON con tablas, columnas y atributos a ignorar")
+	format := flags.String("format", "text", "Formato del informe (text, json, markdown)")
+	output := flags.String("output", "", "Archivo del informe (por defecto la salida estándar)")
+	conn := addConnectionFlags(flags)
+	flags.Usage = func() {
+		fmt.Println("Uso: ./extractor check [-ignore ignorar.json] [-format text|json|markdown] [-output archivo] -dbtype ... -database ... base.json")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return driftError
+	}
+
+	if flags.NArg() != 1 || !conn.isSet() {
+		flags.Usage()
+		return driftError
+	}
+
+	config, err := conn.config()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return driftError
+	}
+
+	var ignore DriftIgnore
+	if *ignoreFile != "" {
+		data, err := os.ReadFile(*ignoreFile)
+		if err != nil {
+			fmt.Printf("Error al leer la lista de exclusiones: %v\n", err)
+			return driftError
+		}
+		err = json.Unmarshal(data, &ignore)
+		if err != nil {
+			fmt.Printf("Error al decodificar la lista de exclusiones: %v\n", err)
+			return driftError
+		}
+	}
+	rules, err := compileDriftIgnore(ignore)
+	if err != nil {
+		fmt.Printf("Error en la lista de exclusiones: %v\n", err)
+		return driftError
+	}
+
+	baseline, err := loadSchemaFromJSONFile(flags.Arg(0))
+	if err != nil {
+		fmt.Println("Error al leer la línea base:", err)
+		return driftError
+	}
+	if baseline.DBType != config.DBType {
+		fmt.Printf("Error: La línea base es de %s y la base en vivo de %s\n", baseline.DBType, config.DBType)
+		return driftError
+	}
+
+	// Con el informe en la salida estándar, el progreso de la extracción y el
+	// resumen final van a stderr para que el informe se pueda procesar (JSON)
+	progress := io.Writer(os.Stdout)
+	if *output == "" {
+		progress = os.Stderr
+	}
+	config.Progress = progress
+
+	// Una tabla que no se pudo extraer aparecería como eliminada
+	live, err := extractLiveSchema(config)
+	if err == nil {
+		err = joinObjectErrors(live.Errors)
+	}
+	if err != nil {
+		fmt.Fprintln(progress, "Error al extraer el esquema:", err)
+		return driftError
+	}
+
+	diff := applyDriftIgnore(diffDatabaseSchemas(baseline, live), rules)
+	diff.Base = flags.Arg(0)
+	diff.Target = fmt.Sprintf("%s://%s:%d/%s", config.DBType, config.Server, config.Port, config.Database)
+
+	diff.Classification = classifySchemaDiff(diff)
+	level := driftLevel(diff.Classification)
+
+	var report string
+	switch strings.ToLower(*format) {
+	case "json":
+		data, err := json.MarshalIndent(DriftReport{Drift: driftLabel(level), Diff: diff}, "", "  ")
+		if err != nil {
+			fmt.Fprintf(progress, "Error al codificar JSON: %v\n", err)
+			return driftError
+		}
+		report = string(data) + "\n"
+	default:
+		report, err = renderSchemaDiff(diff, strings.ToLower(*format))
+		if err != nil {
+			fmt.Fprintln(progress, "Error:", err)
+			return driftError
+		}
+	}
+
+	if *output == "" {
+		fmt.Print(report)
+	} else {
+		err = os.WriteFile(*output, []byte(report), 0644)
+		if err != nil {
+			fmt.Fprintf(progress, "Error al escribir el informe: %v\n", err)
+			return driftError
+		}
+		fmt.Fprintf(progress, "✅ Informe de desvíos guardado en: %s\n", *output)
+	}
+
+	switch level {
+	case driftNone:
+		fmt.Fprintln(progress, "✅ Sin desvíos respecto de la línea base")
+	case driftCompatible:
+		fmt.Fprintln(progress, "⚠️  Desvío compatible respecto de la línea base")
+	default:
+		fmt.Fprintln(progress, "❌ Desvío incompatible respecto de la línea base:")
+		for _, change := range diff.Classification.Breaking() {
+			fmt.Fprintf(progress, "   - %s: %s\n", change.Target(), change.Reason)
+		}
+	}
+
+	return level
+}
+
+func driftLabel(level int) string {
+	switch level {
+	case driftNone:
+		return "none"
+	case driftCompatible:
+		return "compatible"
+	default:
+		return "breaking"
+	}
+}
+
+// Los desvíos solo aditivos o compatibles no rompen a quien usa la línea base
+func driftLevel(classification *SchemaClassification) int {
+	switch classification.Level {
+	case "none":
+		return driftNone
+	case changeBreaking:
+		return driftBreaking
+	default:
+		return driftCompatible
+	}
+}
+
+// Quita del diff lo que coincide con la lista de exclusiones. Las tablas y
+// columnas que quedan sin cambios se eliminan del resultado.
+func applyDriftIgnore(diff *SchemaDiff, rules driftIgnoreRules) *SchemaDiff {
+	attributes := rules.Attributes
+
+	keepChanges := func(changes []AttributeChange) []AttributeChange {
+		var kept []AttributeChange
+		for _, c := range changes {
+			if !attributes[strings.ToLower(c.Attribute)] {
+				kept = append(kept, c)
+			}
+		}
+		return kept
+	}
+
+	keepObjects := func(category string, diffs []ObjectDiff) []ObjectDiff {
+		if attributes[strings.ToLower(category)] {
+			return nil
+		}
+		var kept []ObjectDiff
+		for _, od := range diffs {
+			if od.Change == "modified" {
+				od.Changes = keepChanges(od.Changes)
+				if len(od.Changes) == 0 {
+					continue
+				}
+			}
+			kept = append(kept, od)
+		}
+		return kept
+	}
+
+	filtered := &SchemaDiff{
+		DBType: diff.DBType, Base: diff.Base, Target: diff.Target, Tables: []TableDiff{},
+		Views:     keepObjects("views", diff.Views),
+		Routines:  keepObjects("routines", diff.Routines),
+		Sequences: keepObjects("sequences", diff.Sequences),
+	}
+	for _, td := range diff.Tables {
+		if !rules.Tables.Allows(td.TableName, qualifiedTableName(td.Schema, td.TableName)) ||
+			(td.RenamedFrom != "" && !rules.Tables.Allows(td.RenamedFrom)) {
+			continue
+		}
+
+		td.Changes = keepChanges(td.Changes)
+		if attributes["indexes"] {
+			td.Indexes = nil
+		}
+		td.ForeignKeys = keepObjects("foreignKeys", td.ForeignKeys)
+		td.Checks = keepObjects("checkConstraints", td.Checks)
+		td.Triggers = keepObjects("triggers", td.Triggers)
+
+		var columns []ColumnDiff
+		for _, cd := range td.Columns {
+			if !rules.Columns.Allows(cd.ColumnName, td.TableName+"."+cd.ColumnName,
+				qualifiedTableName(td.Schema, td.TableName)+"."+cd.ColumnName) {
+				continue
+			}
+			if cd.Change == "modified" {
+				cd.Changes = keepChanges(cd.Changes)
+				if len(cd.Changes) == 0 {
+					continue
+				}
+			}
+			columns = append(columns, cd)
+		}
+		td.Columns = columns
+
+		if td.Change == "modified" && !td.hasDetails() {
+			continue
+		}
+		filtered.Tables = append(filtered.Tables, td)
+	}
+
+	return filtered
+}
+
+func matchesAnyPattern(patterns []string, names ...string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, strings.ToLower(name)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyDriftIgnore(t *testing.T) {
+	diff := &SchemaDiff{
+		DBType: "postgres",
+		Tables: []TableDiff{
+			{Schema: "public", TableName: "audit_2024", Change: "added"},
+			{Schema: "public", TableName: "log_15", Change: "removed"},
+			{
+				Schema: "public", TableName: "clientes", Change: "modified",
+				Columns: []ColumnDiff{
+					{ColumnName: "actualizado", Change: "added"},
+					{ColumnName: "nombre", Change: "modified", Changes: []AttributeChange{
+						{Attribute: "defaultValue", Old: "", New: "'x'"},
+					}},
+					{ColumnName: "email", Change: "modified", Changes: []AttributeChange{
+						{Attribute: "maxLength", Old: "100", New: "50"},
+					}},
+				},
+			},
+		},
+	}
+
+	rules, err := compileDriftIgnore(DriftIgnore{
+		Tables:     []string{"AUDIT_*", `/^public\.log_\d+$/`},
+		Columns:    []string{"clientes.actualizado"},
+		Attributes: []string{"defaultvalue"},
+	})
+	if err != nil {
+		t.Fatalf("compileDriftIgnore: %v", err)
+	}
+
+	got := applyDriftIgnore(diff, rules)
+	want := []TableDiff{{
+		Schema: "public", TableName: "clientes", Change: "modified",
+		Columns: []ColumnDiff{
+			{ColumnName: "email", Change: "modified", Changes: []AttributeChange{
+				{Attribute: "maxLength", Old: "100", New: "50"},
+			}},
+		},
+	}}
+	if !reflect.DeepEqual(got.Tables, want) {
+		t.Errorf("applyDriftIgnore = %+v, se esperaba %+v", got.Tables, want)
+	}
+}
+
+func TestCompileDriftIgnoreInvalidPattern(t *testing.T) {
+	tests := []DriftIgnore{
+		{Tables: []string{"audit_[0-9"}},
+		{Columns: []string{"/(sin_cerrar/"}},
+	}
+
+	for _, ignore := range tests {
+		if _, err := compileDriftIgnore(ignore); err == nil {
+			t.Errorf("compileDriftIgnore(%+v) debería fallar", ignore)
+		}
+	}
+}
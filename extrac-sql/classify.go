@@ -0,0 +1,355 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Niveles de impacto de un cambio de esquema, de menor a mayor
+const (
+	changeAdditive   = "additive"
+	changeCompatible = "compatible"
+	changeBreaking   = "breaking"
+)
+
+// Un cambio del diff con su nivel de impacto para quien usa el esquema base
+type ClassifiedChange struct {
+	Schema string `json:"schema,omitempty"`
+	Table  string `json:"table,omitempty"`
+	Column string `json:"column,omitempty"`
+	Index  string `json:"index,omitempty"`
+	// Clave foránea, check o trigger de la tabla, o vista, rutina o secuencia
+	// cuando Table está vacío
+	Object string `json:"object,omitempty"`
+	Level  string `json:"level"`
+	Reason string `json:"reason"`
+}
+
+// Level es el nivel más alto entre los cambios, o none si no hay cambios
+type SchemaClassification struct {
+	Level   string             `json:"level"`
+	Changes []ClassifiedChange `json:"changes"`
+}
+
+// Clasifica las diferencias entre dos esquemas SQL
+func classifySchemaChanges(base, target *DatabaseSchema) *SchemaClassification {
+	return classifySchemaDiff(diffDatabaseSchemas(base, target))
+}
+
+// Clasifica cada cambio como additive (solo agrega), compatible (modifica sin
+// romper a los clientes existentes) o breaking, con el motivo
+func classifySchemaDiff(diff *SchemaDiff) *SchemaClassification {
+	result := &SchemaClassification{Level: "none", Changes: []ClassifiedChange{}}
+
+	record := func(change ClassifiedChange) {
+		result.Changes = append(result.Changes, change)
+		if changeLevelRank(change.Level) > changeLevelRank(result.Level) {
+			result.Level = change.Level
+		}
+	}
+
+	for _, td := range diff.Tables {
+		add := func(column, index, level, reason string) {
+			record(ClassifiedChange{
+				Schema: td.Schema, Table: td.TableName, Column: column, Index: index, Level: level, Reason: reason,
+			})
+		}
+		addObject := func(object, level, reason string) {
+			record(ClassifiedChange{Schema: td.Schema, Table: td.TableName, Object: object, Level: level, Reason: reason})
+		}
+
+		switch td.Change {
+		case "added":
+			add("", "", changeAdditive, "tabla nueva")
+			continue
+		case "removed":
+			add("", "", changeBreaking, "tabla eliminada")
+			continue
+		case "renamed":
+			add("", "", changeBreaking, "tabla renombrada desde "+td.RenamedFrom)
+		}
+
+		for _, c := range td.Changes {
+			level, reason := classifyTableAttribute(c)
+			add("", "", level, reason)
+		}
+
+		for _, cd := range td.Columns {
+			for _, classified := range classifyColumnDiff(cd, diff.DBType) {
+				add(cd.ColumnName, "", classified.Level, classified.Reason)
+			}
+		}
+
+		for _, id := range td.Indexes {
+			level, reason := classifyIndexDiff(id)
+			add("", id.IndexName, level, reason)
+		}
+
+		for _, od := range td.ForeignKeys {
+			level, reason := classifyConstraintDiff(od, "clave foránea")
+			addObject("clave foránea "+od.Name, level, reason)
+		}
+		for _, od := range td.Checks {
+			level, reason := classifyConstraintDiff(od, "restricción CHECK")
+			addObject("check "+od.Name, level, reason)
+		}
+		for _, od := range td.Triggers {
+			addObject("trigger "+od.Name, changeCompatible, "trigger "+triggerChangeLabel(od))
+		}
+	}
+
+	for _, od := range diff.Views {
+		level, reason := classifyViewDiff(od)
+		record(ClassifiedChange{Object: "vista " + od.Name, Level: level, Reason: reason})
+	}
+	for _, od := range diff.Routines {
+		level, reason := classifyRoutineDiff(od)
+		record(ClassifiedChange{Object: "rutina " + od.Name, Level: level, Reason: reason})
+	}
+	for _, od := range diff.Sequences {
+		level, reason := classifySequenceDiff(od)
+		record(ClassifiedChange{Object: "secuencia " + od.Name, Level: level, Reason: reason})
+	}
+
+	return result
+}
+
+func changeLevelRank(level string) int {
+	switch level {
+	case changeAdditive:
+		return 1
+	case changeCompatible:
+		return 2
+	case changeBreaking:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Cambios que rompen la compatibilidad, en el orden del diff
+func (c *SchemaClassification) Breaking() []ClassifiedChange {
+	var breaking []ClassifiedChange
+	for _, change := range c.Changes {
+		if change.Level == changeBreaking {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+// Nombre completo del objeto afectado, para los informes
+func (c ClassifiedChange) Target() string {
+	name := qualifiedTableName(c.Schema, c.Table)
+	switch {
+	case c.Column != "":
+		name += "." + c.Column
+	case c.Index != "":
+		name += " (índice " + c.Index + ")"
+	case c.Object != "" && name == "":
+		return c.Object
+	case c.Object != "":
+		name += " (" + c.Object + ")"
+	}
+	return name
+}
+
+func classifyTableAttribute(c AttributeChange) (string, string) {
+	switch c.Attribute {
+	case "primaryKey":
+		return changeBreaking, "clave primaria modificada: " + formatAttributeChange(c)
+	case "collectionType", "viewOn", "pipeline":
+		return changeBreaking, c.Attribute + " modificado: " + formatAttributeChange(c)
+	case "validator":
+		if c.New == "" {
+			return changeCompatible, "se quitó el validador"
+		}
+		return changeBreaking, "reglas de validación nuevas o modificadas; documentos válidos antes pueden rechazarse"
+	default:
+		return changeCompatible, c.Attribute + " modificado: " + formatAttributeChange(c)
+	}
+}
+
+func classifyColumnDiff(cd ColumnDiff, dbType string) []ClassifiedChange {
+	switch cd.Change {
+	case "added":
+		if cd.New != nil && cd.New.IsNullable == "NO" && cd.New.DefaultValue == "" && !cd.New.IsIdentity {
+			return []ClassifiedChange{{Level: changeBreaking, Reason: "columna NOT NULL sin valor por defecto; los INSERT existentes fallan"}}
+		}
+		return []ClassifiedChange{{Level: changeAdditive, Reason: "columna nueva"}}
+	case "removed":
+		return []ClassifiedChange{{Level: changeBreaking, Reason: "columna eliminada"}}
+	}
+
+	// Campos de MongoDB: solo se conocen los tipos observados
+	if cd.Old == nil || cd.New == nil {
+		for _, c := range cd.Changes {
+			if c.Attribute == "types" && !containsAllTypes(c.New, c.Old) {
+				return []ClassifiedChange{{Level: changeBreaking, Reason: "tipos del campo modificados: " + formatAttributeChange(c)}}
+			}
+		}
+		return []ClassifiedChange{{Level: changeCompatible, Reason: "tipos del campo ampliados"}}
+	}
+
+	var classified []ClassifiedChange
+	typeChecked := false
+	for _, c := range cd.Changes {
+		switch c.Attribute {
+		case "dataType", "maxLength", "precision", "scale", "datetimePrecision", "precisionUnspecified", "isUnsigned", "lengthSemantics":
+			if typeChecked {
+				continue
+			}
+			typeChecked = true
+			oldType, newType := describeColumnType(*cd.Old, dbType), describeColumnType(*cd.New, dbType)
+			if cd.Old.LengthSemantics != cd.New.LengthSemantics {
+				oldType = strings.TrimSpace(oldType + " " + cd.Old.LengthSemantics)
+				newType = strings.TrimSpace(newType + " " + cd.New.LengthSemantics)
+			}
+			change := oldType + " → " + newType
+			if isNarrowingChange(*cd.Old, *cd.New, dbType) {
+				classified = append(classified, ClassifiedChange{Level: changeBreaking, Reason: "tipo reducido o cambiado: " + change})
+			} else {
+				classified = append(classified, ClassifiedChange{Level: changeCompatible, Reason: "tipo ampliado: " + change})
+			}
+		case "isNullable":
+			if c.New == "NO" {
+				classified = append(classified, ClassifiedChange{Level: changeBreaking, Reason: "la columna deja de aceptar NULL"})
+			} else {
+				classified = append(classified, ClassifiedChange{Level: changeCompatible, Reason: "la columna pasa a aceptar NULL"})
+			}
+		case "defaultValue":
+			classified = append(classified, ClassifiedChange{Level: changeCompatible, Reason: "valor por defecto modificado: " + formatAttributeChange(c)})
+		case "isIdentity":
+			classified = append(classified, ClassifiedChange{Level: changeBreaking, Reason: "identity modificado: " + formatAttributeChange(c)})
+		}
+		// isPrimaryKey se informa una sola vez como cambio de la tabla
+	}
+
+	return classified
+}
+
+func classifyIndexDiff(id IndexDiff) (string, string) {
+	switch id.Change {
+	case "added":
+		if strings.Contains(id.New, " unique") {
+			return changeBreaking, "índice único nuevo; los datos duplicados se rechazan"
+		}
+		return changeAdditive, "índice nuevo"
+	case "removed":
+		return changeCompatible, "índice eliminado"
+	default:
+		if strings.Contains(id.New, " unique") && !strings.Contains(id.Old, " unique") {
+			return changeBreaking, "el índice pasa a ser único"
+		}
+		return changeCompatible, "índice modificado: " + describeIndexDiff(id)
+	}
+}
+
+// Claves foráneas y restricciones CHECK: una nueva o modificada puede
+// rechazar filas que antes se aceptaban
+func classifyConstraintDiff(od ObjectDiff, label string) (string, string) {
+	switch od.Change {
+	case "added":
+		return changeBreaking, label + " nueva; se rechazan filas que antes eran válidas"
+	case "removed":
+		return changeCompatible, label + " eliminada"
+	default:
+		return changeBreaking, label + " modificada: " + describeObjectDiff(od)
+	}
+}
+
+func triggerChangeLabel(od ObjectDiff) string {
+	switch od.Change {
+	case "added":
+		return "agregado"
+	case "removed":
+		return "eliminado"
+	default:
+		return "modificado: " + describeObjectDiff(od)
+	}
+}
+
+func classifyViewDiff(od ObjectDiff) (string, string) {
+	switch od.Change {
+	case "added":
+		return changeAdditive, "vista nueva"
+	case "removed":
+		return changeBreaking, "vista eliminada"
+	}
+
+	for _, c := range od.Changes {
+		if c.Attribute == "columns" && !containsAllNames(c.New, c.Old) {
+			return changeBreaking, "la vista deja de exponer columnas: " + formatAttributeChange(c)
+		}
+	}
+	return changeCompatible, "vista modificada: " + describeObjectDiff(od)
+}
+
+func classifyRoutineDiff(od ObjectDiff) (string, string) {
+	switch od.Change {
+	case "added":
+		return changeAdditive, "rutina nueva"
+	case "removed":
+		return changeBreaking, "rutina eliminada"
+	}
+
+	for _, c := range od.Changes {
+		switch c.Attribute {
+		case "kind", "parameters", "returnType":
+			return changeBreaking, "firma de la rutina modificada: " + describeObjectDiff(od)
+		}
+	}
+	return changeCompatible, "rutina modificada: " + describeObjectDiff(od)
+}
+
+func classifySequenceDiff(od ObjectDiff) (string, string) {
+	switch od.Change {
+	case "added":
+		return changeAdditive, "secuencia nueva"
+	case "removed":
+		return changeBreaking, "secuencia eliminada"
+	default:
+		return changeCompatible, "secuencia modificada: " + describeObjectDiff(od)
+	}
+}
+
+// Indica si la lista nueva (separada por comas) incluye todos los nombres
+// de la anterior
+func containsAllNames(newNames, oldNames string) bool {
+	available := make(map[string]bool)
+	for _, name := range strings.Split(newNames, ", ") {
+		available[name] = true
+	}
+	for _, name := range strings.Split(oldNames, ", ") {
+		if name != "" && !available[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// Indica si los tipos nuevos (separados por |) incluyen todos los anteriores
+func containsAllTypes(newTypes, oldTypes string) bool {
+	available := make(map[string]bool)
+	for _, t := range strings.Split(newTypes, "|") {
+		available[t] = true
+	}
+	for _, t := range strings.Split(oldTypes, "|") {
+		if t != "" && !available[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// Resumen de la clasificación para los informes de texto
+func describeClassification(c *SchemaClassification) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Clasificación: %s\n", c.Level)
+	for _, change := range c.Changes {
+		fmt.Fprintf(&sb, "  [%s] %s: %s\n", change.Level, change.Target(), change.Reason)
+	}
+
+	return sb.String()
+}
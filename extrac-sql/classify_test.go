@@ -0,0 +1,136 @@
+package main
+
+import "testing"
+
+func TestClassifyColumnDiff(t *testing.T) {
+	varchar50 := &Column{ColumnName: "nombre", DataType: "varchar", MaxLength: 50, IsNullable: "YES"}
+	varchar100 := &Column{ColumnName: "nombre", DataType: "varchar", MaxLength: 100, IsNullable: "YES"}
+	notNull := &Column{ColumnName: "nombre", DataType: "varchar", MaxLength: 50, IsNullable: "NO"}
+	withDefault := &Column{ColumnName: "nombre", DataType: "varchar", MaxLength: 50, IsNullable: "NO", DefaultValue: "''"}
+	intColumn := &Column{ColumnName: "cantidad", DataType: "int", Precision: 10, IsNullable: "YES"}
+	bigintColumn := &Column{ColumnName: "cantidad", DataType: "bigint", Precision: 19, IsNullable: "YES"}
+
+	modified := func(old, new *Column) ColumnDiff {
+		return ColumnDiff{ColumnName: new.ColumnName, Change: "modified", Changes: diffColumns(old, new), Old: old, New: new}
+	}
+
+	tests := []struct {
+		name string
+		cd   ColumnDiff
+		want []string
+	}{
+		{"columna nueva que acepta NULL", ColumnDiff{Change: "added", New: varchar50}, []string{changeAdditive}},
+		{"columna nueva NOT NULL sin default", ColumnDiff{Change: "added", New: notNull}, []string{changeBreaking}},
+		{"columna nueva NOT NULL con default", ColumnDiff{Change: "added", New: withDefault}, []string{changeAdditive}},
+		{"columna eliminada", ColumnDiff{Change: "removed", Old: varchar50}, []string{changeBreaking}},
+		{"longitud ampliada", modified(varchar50, varchar100), []string{changeCompatible}},
+		{"longitud reducida", modified(varchar100, varchar50), []string{changeBreaking}},
+		{"int a bigint", modified(intColumn, bigintColumn), []string{changeCompatible}},
+		{"bigint a int", modified(bigintColumn, intColumn), []string{changeBreaking}},
+		{"deja de aceptar NULL", modified(varchar50, notNull), []string{changeBreaking}},
+		{"pasa a aceptar NULL", modified(notNull, varchar50), []string{changeCompatible}},
+		{"default y NOT NULL", modified(varchar50, withDefault), []string{changeBreaking, changeCompatible}},
+		{"tipos de MongoDB ampliados", ColumnDiff{Change: "modified",
+			Changes: []AttributeChange{{Attribute: "types", Old: "string", New: "int|string"}}}, []string{changeCompatible}},
+		{"tipos de MongoDB reducidos", ColumnDiff{Change: "modified",
+			Changes: []AttributeChange{{Attribute: "types", Old: "int|string", New: "string"}}}, []string{changeBreaking}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classified := classifyColumnDiff(tt.cd, "sqlserver")
+
+			var levels []string
+			for _, c := range classified {
+				levels = append(levels, c.Level)
+			}
+			if len(levels) != len(tt.want) {
+				t.Fatalf("niveles = %v, se esperaba %v (%+v)", levels, tt.want, classified)
+			}
+			for i := range levels {
+				if levels[i] != tt.want[i] {
+					t.Errorf("niveles = %v, se esperaba %v (%+v)", levels, tt.want, classified)
+					break
+				}
+			}
+		})
+	}
+}
+
+// Los tipos numéricos sin precisión no tienen límite de dígitos
+func TestClassifyColumnDiffUnlimitedPrecision(t *testing.T) {
+	tests := []struct {
+		name   string
+		dbType string
+		old    *Column
+		new    *Column
+		want   string
+	}{
+		{"numeric(10,2) a numeric", "postgres",
+			&Column{ColumnName: "importe", DataType: "numeric", Precision: 10, Scale: 2},
+			&Column{ColumnName: "importe", DataType: "numeric"}, changeCompatible},
+		{"numeric a numeric(10,2)", "postgres",
+			&Column{ColumnName: "importe", DataType: "numeric"},
+			&Column{ColumnName: "importe", DataType: "numeric", Precision: 10, Scale: 2}, changeBreaking},
+		{"NUMBER(10) a NUMBER", "oracle",
+			&Column{ColumnName: "IMPORTE", DataType: "number", Precision: 10},
+			&Column{ColumnName: "IMPORTE", DataType: "number"}, changeCompatible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cd := ColumnDiff{ColumnName: tt.new.ColumnName, Change: "modified",
+				Changes: diffColumns(tt.old, tt.new), Old: tt.old, New: tt.new}
+			classified := classifyColumnDiff(cd, tt.dbType)
+			if len(classified) != 1 || classified[0].Level != tt.want {
+				t.Errorf("classifyColumnDiff() = %+v, se esperaba %s", classified, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifySchemaDiffLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		diff *SchemaDiff
+		want string
+	}{
+		{"sin cambios", &SchemaDiff{}, "none"},
+		{"tabla nueva", &SchemaDiff{Tables: []TableDiff{{TableName: "t", Change: "added"}}}, changeAdditive},
+		{"índice eliminado", &SchemaDiff{Tables: []TableDiff{{TableName: "t", Change: "modified",
+			Indexes: []IndexDiff{{IndexName: "ix", Change: "removed", Old: "(a ASC)"}}}}}, changeCompatible},
+		{"índice único nuevo", &SchemaDiff{Tables: []TableDiff{{TableName: "t", Change: "modified",
+			Indexes: []IndexDiff{{IndexName: "ux", Change: "added", New: "(a ASC) unique"}}}}}, changeBreaking},
+		{"clave foránea nueva", &SchemaDiff{Tables: []TableDiff{{TableName: "t", Change: "modified",
+			ForeignKeys: []ObjectDiff{{Name: "fk", Change: "added", New: "(a) → dbo.r(id)"}}}}}, changeBreaking},
+		{"clave foránea eliminada", &SchemaDiff{Tables: []TableDiff{{TableName: "t", Change: "modified",
+			ForeignKeys: []ObjectDiff{{Name: "fk", Change: "removed", Old: "(a) → dbo.r(id)"}}}}}, changeCompatible},
+		{"check nuevo", &SchemaDiff{Tables: []TableDiff{{TableName: "t", Change: "modified",
+			Checks: []ObjectDiff{{Name: "ck", Change: "added", New: "precio > 0"}}}}}, changeBreaking},
+		{"check eliminado", &SchemaDiff{Tables: []TableDiff{{TableName: "t", Change: "modified",
+			Checks: []ObjectDiff{{Name: "ck", Change: "removed", Old: "precio > 0"}}}}}, changeCompatible},
+		{"trigger nuevo", &SchemaDiff{Tables: []TableDiff{{TableName: "t", Change: "modified",
+			Triggers: []ObjectDiff{{Name: "tr", Change: "added", New: "AFTER INSERT"}}}}}, changeCompatible},
+		{"vista nueva", &SchemaDiff{Views: []ObjectDiff{{Name: "v", Change: "added"}}}, changeAdditive},
+		{"vista con una columna más", &SchemaDiff{Views: []ObjectDiff{{Name: "v", Change: "modified",
+			Changes: []AttributeChange{{Attribute: "columns", Old: "a", New: "a, b"}}}}}, changeCompatible},
+		{"vista sin una columna", &SchemaDiff{Views: []ObjectDiff{{Name: "v", Change: "modified",
+			Changes: []AttributeChange{{Attribute: "columns", Old: "a, b", New: "a"}}}}}, changeBreaking},
+		{"rutina nueva", &SchemaDiff{Routines: []ObjectDiff{{Name: "r", Change: "added"}}}, changeAdditive},
+		{"cuerpo de la rutina modificado", &SchemaDiff{Routines: []ObjectDiff{{Name: "r", Change: "modified",
+			Changes: []AttributeChange{{Attribute: "definition", Old: "BEGIN END", New: "BEGIN SELECT 1; END"}}}}}, changeCompatible},
+		{"parámetros de la rutina modificados", &SchemaDiff{Routines: []ObjectDiff{{Name: "r", Change: "modified",
+			Changes: []AttributeChange{{Attribute: "parameters", Old: "IN a integer", New: "IN a bigint"}}}}}, changeBreaking},
+		{"secuencia eliminada", &SchemaDiff{Sequences: []ObjectDiff{{Name: "s", Change: "removed"}}}, changeBreaking},
+		{"incremento de la secuencia modificado", &SchemaDiff{Sequences: []ObjectDiff{{Name: "s", Change: "modified",
+			Changes: []AttributeChange{{Attribute: "increment", Old: "1", New: "10"}}}}}, changeCompatible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifySchemaDiff(tt.diff).Level; got != tt.want {
+				t.Errorf("nivel = %s, se esperaba %s", got, tt.want)
+			}
+		})
+	}
+}
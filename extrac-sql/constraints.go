@@ -0,0 +1,414 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Cláusula CHECK con su nombre opcional en una sentencia CREATE TABLE o en el
+// texto de una restricción de Sybase; la expresión empieza en el paréntesis
+var checkClause = regexp.MustCompile("(?i)(?:\\bCONSTRAINT\\s+(\"[^\"]+\"|\\[[^\\]]+\\]|`[^`]+`|\\w+)\\s+)?\\bCHECK\\s*\\(")
+
+// Texto de un default de Sybase: "DEFAULT valor" si se declaró en la columna
+// o "create default nombre as valor" si se enlazó con sp_bindefault
+var sybaseDefaultText = regexp.MustCompile(`(?is)^\s*(?:create\s+default\s+\S+\s+as|default)\s+(.*)$`)
+
+// Restricciones NOT NULL que Oracle guarda como restricciones CHECK
+var oracleNotNullCheck = regexp.MustCompile(`^"[^"]+" IS NOT NULL$`)
+
+// Estructura para almacenar una restricción CHECK. Expression es la condición
+// sin la palabra CHECK ni los paréntesis exteriores, tal como la guarda el motor.
+type CheckConstraint struct {
+	ConstraintName string   `json:"constraintName"`
+	Expression     string   `json:"expression"`
+	Columns        []string `json:"columns,omitempty"`
+}
+
+func extractCheckConstraints(db *sql.DB, dbType, schemaName, tableName string, columns []Column) ([]CheckConstraint, error) {
+	// Para Sybase, construimos la consulta dinámicamente sin parámetros
+	if dbType == "sybase" {
+		return extractSybaseCheckConstraints(db, schemaName, tableName, columns)
+	}
+
+	if dbType == "sqlite" {
+		return extractSQLiteCheckConstraints(db, tableName, columns)
+	}
+
+	query := getCheckConstraintsQuery(dbType)
+	var rows *sql.Rows
+	var err error
+
+	switch dbType {
+	case "sqlserver":
+		rows, err = db.Query(query, sql.Named("schema", schemaName), sql.Named("table", tableName))
+	case "mysql", "postgres", "oracle":
+		rows, err = db.Query(query, schemaName, tableName)
+	default:
+		return nil, fmt.Errorf("tipo de base de datos no soportado: %s", dbType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar restricciones check: %v", err)
+	}
+	defer rows.Close()
+
+	var checks []CheckConstraint
+	for rows.Next() {
+		var name string
+		var expression, referenced sql.NullString
+
+		err := rows.Scan(&name, &expression, &referenced)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear restricción check: %v", err)
+		}
+
+		check := CheckConstraint{
+			ConstraintName: name,
+			Expression:     normalizeCheckExpression(expression.String),
+		}
+		if dbType == "oracle" && oracleNotNullCheck.MatchString(check.Expression) {
+			continue
+		}
+
+		// Si el catálogo no informa las columnas se buscan en la expresión
+		if referenced.String != "" {
+			check.Columns = strings.Split(referenced.String, ",")
+		} else {
+			check.Columns = checkConstraintColumns(check.Expression, columns)
+		}
+
+		checks = append(checks, check)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre restricciones check: %v", err)
+	}
+
+	return checks, nil
+}
+
+// Todas las consultas devuelven nombre, expresión y columnas separadas por
+// coma (vacío si el catálogo solo las informa para restricciones de columna)
+func getCheckConstraintsQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				cc.name AS CONSTRAINT_NAME,
+				cc.definition AS EXPRESSION,
+				COALESCE(COL_NAME(cc.parent_object_id, cc.parent_column_id), '') AS COLUMNS
+			FROM sys.check_constraints cc
+			WHERE cc.parent_object_id = OBJECT_ID(QUOTENAME(@schema) + '.' + QUOTENAME(@table))
+			ORDER BY cc.name
+		`
+	case "mysql":
+		// CHECK_CONSTRAINTS existe desde MySQL 8.0.16
+		return `
+			SELECT
+				cc.CONSTRAINT_NAME,
+				cc.CHECK_CLAUSE,
+				'' AS COLUMNS
+			FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+			JOIN INFORMATION_SCHEMA.CHECK_CONSTRAINTS cc
+				ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+				AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+			WHERE tc.TABLE_SCHEMA = ?
+			AND tc.TABLE_NAME = ?
+			AND tc.CONSTRAINT_TYPE = 'CHECK'
+			ORDER BY cc.CONSTRAINT_NAME
+		`
+	case "postgres":
+		return `
+			SELECT
+				c.conname,
+				pg_get_constraintdef(c.oid, true),
+				COALESCE((
+					SELECT string_agg(a.attname, ',' ORDER BY a.attnum)
+					FROM pg_attribute a
+					WHERE a.attrelid = c.conrelid
+					AND a.attnum = ANY(c.conkey)
+				), '')
+			FROM pg_constraint c
+			WHERE c.conrelid = format('%I.%I', $1::text, $2::text)::regclass
+			AND c.contype = 'c'
+			ORDER BY c.conname
+		`
+	case "oracle":
+		// Las restricciones NOT NULL también son de tipo C; se descartan al
+		// escanear porque SEARCH_CONDITION es LONG y no admite LIKE
+		return `
+			SELECT
+				c.constraint_name,
+				c.search_condition,
+				(SELECT LISTAGG(cc.column_name, ',') WITHIN GROUP (ORDER BY cc.column_name)
+				 FROM all_cons_columns cc
+				 WHERE cc.owner = c.owner
+				 AND cc.constraint_name = c.constraint_name) AS columns
+			FROM all_constraints c
+			WHERE c.owner = :1
+			AND c.table_name = :2
+			AND c.constraint_type = 'C'
+			ORDER BY c.constraint_name
+		`
+	default:
+		return ""
+	}
+}
+
+// Quita la palabra CHECK, las opciones de PostgreSQL y los paréntesis
+// exteriores que agregan SQL Server y MySQL
+func normalizeCheckExpression(expression string) string {
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(strings.ToUpper(expression), "CHECK") {
+		expression = strings.TrimSpace(expression[len("CHECK"):])
+	}
+	for _, option := range []string{" NOT VALID", " NO INHERIT"} {
+		expression = strings.TrimSpace(strings.TrimSuffix(expression, option))
+	}
+	return stripOuterParens(expression)
+}
+
+// Devuelve las columnas de la tabla que aparecen en la expresión, en el
+// orden de la tabla. Se ignoran los literales de texto.
+func checkConstraintColumns(expression string, columns []Column) []string {
+	found := make(map[string]bool)
+	var word strings.Builder
+	var quote rune
+
+	flush := func() {
+		if word.Len() > 0 {
+			found[strings.ToLower(word.String())] = true
+			word.Reset()
+		}
+	}
+
+	for _, r := range expression {
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			}
+		case quote != 0:
+			if r == quote {
+				flush()
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'':
+			flush()
+			quote = r
+		case r == '"' || r == '`':
+			flush()
+			quote = r
+		case r == '[':
+			flush()
+			quote = ']'
+		case r == '_' || r == '$' || r == '#' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	var referenced []string
+	for _, col := range columns {
+		if found[strings.ToLower(col.ColumnName)] {
+			referenced = append(referenced, col.ColumnName)
+		}
+	}
+	return referenced
+}
+
+// Busca las cláusulas CHECK de una sentencia CREATE TABLE o del texto de una
+// restricción. Las restricciones sin nombre quedan con nombre vacío.
+func parseCheckClauses(text string) []CheckConstraint {
+	var checks []CheckConstraint
+
+	for _, match := range checkClause.FindAllStringSubmatchIndex(text, -1) {
+		open := match[1] - 1
+		end := matchingParen(text, open)
+		if end < 0 {
+			continue
+		}
+
+		var name string
+		if match[2] >= 0 {
+			name = unquoteIdentifier(text[match[2]:match[3]])
+		}
+
+		checks = append(checks, CheckConstraint{
+			ConstraintName: name,
+			Expression:     stripOuterParens(strings.TrimSpace(text[open+1 : end])),
+		})
+	}
+
+	return checks
+}
+
+// Devuelve la posición del paréntesis que cierra el de la posición open, o -1
+func matchingParen(text string, open int) int {
+	depth := 0
+	inString := false
+	for i := open; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\'':
+			inString = !inString
+		case inString:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func unquoteIdentifier(name string) string {
+	if len(name) >= 2 {
+		switch name[0] {
+		case '"', '`', '[':
+			return name[1 : len(name)-1]
+		}
+	}
+	return name
+}
+
+// Función específica para extraer restricciones CHECK de Sybase (sin
+// parámetros). El bit 128 de sysconstraints.status indica una restricción
+// CHECK; su texto está en syscomments.
+func extractSybaseCheckConstraints(db *sql.DB, schemaName, tableName string, columns []Column) ([]CheckConstraint, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			object_name(c.constrid) as constraint_name,
+			ISNULL(col_name(c.tableid, c.colid), '') as column_name
+		FROM sysconstraints c
+		WHERE c.tableid = object_id('%s.%s')
+		AND c.status & 128 = 128
+		ORDER BY 1
+	`, schemaName, tableName)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar restricciones check: %v", err)
+	}
+	defer rows.Close()
+
+	var checks []CheckConstraint
+	for rows.Next() {
+		var check CheckConstraint
+		var columnName string
+
+		err := rows.Scan(&check.ConstraintName, &columnName)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear restricción check: %v", err)
+		}
+		if columnName != "" {
+			check.Columns = []string{columnName}
+		}
+
+		checks = append(checks, check)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre restricciones check: %v", err)
+	}
+	rows.Close()
+
+	for i := range checks {
+		text, err := getSybaseObjectText(db, schemaName, checks[i].ConstraintName)
+		if err != nil {
+			return nil, fmt.Errorf("error al extraer el texto de la restricción %s: %v", checks[i].ConstraintName, err)
+		}
+		if parsed := parseCheckClauses(text); len(parsed) > 0 {
+			checks[i].Expression = parsed[0].Expression
+		}
+		if len(checks[i].Columns) == 0 {
+			checks[i].Columns = checkConstraintColumns(checks[i].Expression, columns)
+		}
+	}
+
+	return checks, nil
+}
+
+// Función específica para extraer restricciones CHECK de SQLite. No hay
+// catálogo de restricciones, así que se leen de la sentencia CREATE TABLE
+// guardada en sqlite_master; las que no tienen nombre reciben uno generado.
+func extractSQLiteCheckConstraints(db *sql.DB, tableName string, columns []Column) ([]CheckConstraint, error) {
+	var createSQL string
+
+	err := db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?`, tableName).Scan(&createSQL)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar definición de tabla: %v", err)
+	}
+
+	checks := parseCheckClauses(createSQL)
+	for i := range checks {
+		if checks[i].ConstraintName == "" {
+			checks[i].ConstraintName = fmt.Sprintf("ck_%s_%d", tableName, i)
+		}
+		checks[i].Columns = checkConstraintColumns(checks[i].Expression, columns)
+	}
+
+	return checks, nil
+}
+
+// Completa DefaultConstraint con el nombre de las restricciones DEFAULT de
+// SQL Server. En Sybase el nombre y el valor se leen al extraer las columnas.
+func addDefaultConstraintNames(db *sql.DB, dbType, schemaName, tableName string, columns []Column) error {
+	if dbType != "sqlserver" {
+		return nil
+	}
+
+	rows, err := db.Query(`
+		SELECT
+			COL_NAME(dc.parent_object_id, dc.parent_column_id) AS COLUMN_NAME,
+			dc.name AS CONSTRAINT_NAME
+		FROM sys.default_constraints dc
+		WHERE dc.parent_object_id = OBJECT_ID(QUOTENAME(@schema) + '.' + QUOTENAME(@table))
+	`, sql.Named("schema", schemaName), sql.Named("table", tableName))
+	if err != nil {
+		return fmt.Errorf("error al consultar restricciones default: %v", err)
+	}
+	defer rows.Close()
+
+	names := make(map[string]string)
+	for rows.Next() {
+		var columnName, constraintName string
+		if err := rows.Scan(&columnName, &constraintName); err != nil {
+			return fmt.Errorf("error al escanear restricción default: %v", err)
+		}
+		names[columnName] = constraintName
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterando sobre restricciones default: %v", err)
+	}
+
+	for i := range columns {
+		columns[i].DefaultConstraint = names[columns[i].ColumnName]
+	}
+
+	return nil
+}
+
+// Lee el valor de un default de Sybase desde su texto en syscomments
+func getSybaseDefaultValue(db *sql.DB, defaultID int) (string, error) {
+	text, err := querySybaseComments(db, fmt.Sprintf("%d", defaultID))
+	if err != nil {
+		return "", err
+	}
+
+	if match := sybaseDefaultText.FindStringSubmatch(text); match != nil {
+		return strings.TrimSpace(match[1]), nil
+	}
+	return text, nil
+}
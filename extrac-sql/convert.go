@@ -0,0 +1,1419 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Motores SQL que pueden usarse como origen de una conversión
+var conversionSources = []string{"sqlserver", "sybase", "mysql", "postgres", "oracle", "sqlite"}
+
+// Tipo de dato independiente del motor. Kind es uno de: boolean, tinyint,
+// smallint, integer, bigint, decimal, money, float, double, char, varchar,
+// text, binary, varbinary, blob, bit, date, time, datetime, timestamptz,
+// interval, rowversion, uuid, json, xml, enum, array o unknown.
+type canonicalType struct {
+	Kind      string
+	Length    int // caracteres o bytes; 0 si no aplica
+	Precision int // 0 en decimal significa precisión arbitraria
+	Scale     int
+	// Dígitos de fracción de segundo en los tipos de fecha y hora
+	FractionalSeconds int
+	// datetime de SQL Server y Sybase: milisegundos redondeados a 1/300 s
+	ApproximateMillis bool
+	Unsigned          bool
+	Unicode           bool
+	// Nombre del tipo de origen cuando Kind es unknown
+	SourceName string
+}
+
+// Reglas de conversión definidas por el usuario en un archivo JSON (-typemap).
+// Los tipos destino se escriben como en el DDL, p. ej. "numeric(19,4)".
+type TypeOverrides struct {
+	Types   map[string]string `json:"types,omitempty"`   // tipo de origen -> tipo destino
+	Columns map[string]string `json:"columns,omitempty"` // [schema.]tabla.columna -> tipo destino
+	Schemas map[string]string `json:"schemas,omitempty"` // schema de origen -> schema destino
+}
+
+// Informe de una conversión: cómo se tradujo cada columna y qué cambios
+// pueden perder datos o alterar el comportamiento
+type ConversionReport struct {
+	SourceDBType string             `json:"sourceDbType"`
+	TargetDBType string             `json:"targetDbType"`
+	Tables       int                `json:"tables"`
+	Columns      []ColumnConversion `json:"columns"`
+	Issues       []ConversionIssue  `json:"issues"`
+}
+
+type ColumnConversion struct {
+	Schema     string `json:"schema"`
+	Table      string `json:"table"`
+	Column     string `json:"column"`
+	SourceType string `json:"sourceType"`
+	TargetType string `json:"targetType"`
+	Canonical  string `json:"canonicalType"`
+	Override   bool   `json:"override,omitempty"`
+}
+
+// Severity es lossy (puede perder datos), warning (cambia el comportamiento)
+// o info (requiere atención al migrar pero no pierde datos)
+type ConversionIssue struct {
+	Schema     string `json:"schema"`
+	Table      string `json:"table"`
+	Column     string `json:"column,omitempty"`
+	SourceType string `json:"sourceType,omitempty"`
+	TargetType string `json:"targetType,omitempty"`
+	Severity   string `json:"severity"`
+	Reason     string `json:"reason"`
+}
+
+type conversionNote struct {
+	Severity string
+	Reason   string
+}
+
+var typeSpecPattern = regexp.MustCompile(`^\s*([^(]+?)\s*(?:\(\s*(max|\d+)\s*(?:,\s*(\d+)\s*)?\))?\s*(unsigned)?\s*$`)
+
+var numericLiteral = regexp.MustCompile(`^[-+]?\d+(\.\d+)?$`)
+
+// Convierte el esquema al dialecto indicado con -target, muestra un resumen
+// de los cambios con pérdida y guarda el informe si se indicó -report
+func applyConversion(schema *DatabaseSchema, config Config) (*DatabaseSchema, error) {
+	if config.Target == "" {
+		return schema, nil
+	}
+
+	overrides := &TypeOverrides{}
+	if config.TypeMap != "" {
+		var err error
+		overrides, err = loadTypeOverrides(config.TypeMap)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	converted, report, err := convertSchema(schema, config.Target, overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	printConversionSummary(report)
+
+	if config.Report != "" {
+		if err := saveToJSONFile(report, config.Report); err != nil {
+			return nil, err
+		}
+		fmt.Printf("📝 Informe de conversión guardado en: %s\n", config.Report)
+	}
+
+	return converted, nil
+}
+
+func printConversionSummary(report *ConversionReport) {
+	counts := map[string]int{}
+	for _, issue := range report.Issues {
+		counts[issue.Severity]++
+	}
+
+	fmt.Printf("🔄 Conversión %s → %s: %d columnas (%d con pérdida, %d advertencias, %d notas)\n",
+		report.SourceDBType, report.TargetDBType, len(report.Columns),
+		counts["lossy"], counts["warning"], counts["info"])
+
+	for _, issue := range report.Issues {
+		if issue.Severity == "info" {
+			continue
+		}
+		icon := "⚠️"
+		if issue.Severity == "lossy" {
+			icon = "❌"
+		}
+		target := issue.Table
+		if issue.Column != "" {
+			target += "." + issue.Column
+		}
+		if issue.SourceType != "" {
+			fmt.Printf("  %s %s: %s → %s (%s)\n", icon, target, issue.SourceType, issue.TargetType, issue.Reason)
+		} else {
+			fmt.Printf("  %s %s: %s\n", icon, target, issue.Reason)
+		}
+	}
+}
+
+func loadTypeOverrides(filename string) (*TypeOverrides, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer reglas de conversión: %v", err)
+	}
+
+	var overrides TypeOverrides
+	err = json.Unmarshal(data, &overrides)
+	if err != nil {
+		return nil, fmt.Errorf("error al decodificar reglas de conversión: %v", err)
+	}
+
+	// Los tipos y columnas se comparan sin distinguir mayúsculas
+	overrides.Types = lowerKeys(overrides.Types)
+	overrides.Columns = lowerKeys(overrides.Columns)
+
+	for _, spec := range overrides.Types {
+		if !typeSpecPattern.MatchString(spec) {
+			return nil, fmt.Errorf("tipo destino no válido en reglas de conversión: %s", spec)
+		}
+	}
+	for _, spec := range overrides.Columns {
+		if !typeSpecPattern.MatchString(spec) {
+			return nil, fmt.Errorf("tipo destino no válido en reglas de conversión: %s", spec)
+		}
+	}
+
+	return &overrides, nil
+}
+
+func lowerKeys(m map[string]string) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[strings.ToLower(k)] = v
+	}
+	return result
+}
+
+// Traduce todas las tablas del esquema al dialecto destino pasando cada
+// columna por el tipo canónico. Claves foráneas e índices se copian con
+// los schemas traducidos.
+func convertSchema(schema *DatabaseSchema, target string, overrides *TypeOverrides) (*DatabaseSchema, *ConversionReport, error) {
+	source := schema.DBType
+	if !isConversionSource(source) {
+		return nil, nil, fmt.Errorf("tipo de base de datos de origen no soportado para conversión: %s", source)
+	}
+	if !isDDLDialect(target) {
+		return nil, nil, fmt.Errorf("dialecto destino no soportado: %s (válidos: %s)", target, strings.Join(ddlDialects, ", "))
+	}
+	if overrides == nil {
+		overrides = &TypeOverrides{}
+	}
+
+	report := &ConversionReport{
+		SourceDBType: source,
+		TargetDBType: target,
+		Tables:       len(schema.Tables),
+		Columns:      []ColumnConversion{},
+		Issues:       []ConversionIssue{},
+	}
+
+	converted := &DatabaseSchema{
+		DatabaseName: schema.DatabaseName,
+		DBType:       target,
+		Schema:       convertSchemaName(schema.Schema, schema, target, overrides),
+		Errors:       schema.Errors,
+	}
+
+	for _, table := range schema.Tables {
+		newTable := Table{
+			TableName:   table.TableName,
+			Schema:      convertSchemaName(table.Schema, schema, target, overrides),
+			Columns:     []Column{},
+			Description: table.Description,
+		}
+
+		for _, col := range table.Columns {
+			newCol := convertColumn(col, table, source, target, overrides, report)
+			newTable.Columns = append(newTable.Columns, newCol)
+		}
+
+		for _, fk := range table.ForeignKeys {
+			fk.ReferencedSchema = convertSchemaName(fk.ReferencedSchema, schema, target, overrides)
+			newTable.ForeignKeys = append(newTable.ForeignKeys, fk)
+
+			// Sybase ASE solo admite restricciones declarativas sin acciones
+			if target == "sybase" && (isCascadingAction(fk.OnDelete) || isCascadingAction(fk.OnUpdate)) {
+				report.addIssue(table, "", "", "", "warning",
+					fmt.Sprintf("la clave foránea %s usa acciones en cascada que Sybase no soporta; deben implementarse con triggers", fk.ConstraintName))
+			}
+		}
+
+		for _, idx := range table.Indexes {
+			newTable.Indexes = append(newTable.Indexes, convertIndex(idx, table, target, report))
+		}
+
+		for _, trigger := range table.Triggers {
+			report.addIssue(table, "", "", "", "warning",
+				fmt.Sprintf("el trigger %s no se convierte; su código debe reescribirse para el dialecto destino", trigger.TriggerName))
+		}
+
+		// La expresión usa las funciones y la sintaxis del motor de origen
+		for _, check := range table.CheckConstraints {
+			report.addIssue(table, "", "", "", "warning",
+				fmt.Sprintf("la restricción check %s no se convierte; su expresión debe reescribirse para el dialecto destino", check.ConstraintName))
+		}
+
+		if table.WithoutRowID || table.Strict {
+			report.addIssue(table, "", "", "", "info", "se descartan las opciones de tabla WITHOUT ROWID/STRICT de SQLite")
+		}
+
+		converted.Tables = append(converted.Tables, newTable)
+	}
+
+	// Las secuencias propias de una columna las reemplaza la identidad
+	for _, seq := range schema.Sequences {
+		if seq.OwnedBy != "" {
+			continue
+		}
+		if !supportsSequences(target) {
+			report.addIssue(Table{Schema: seq.Schema, TableName: seq.SequenceName}, "", "", "", "warning",
+				fmt.Sprintf("%s no admite secuencias; deben reemplazarse por columnas identity o una tabla de numeradores", target))
+			continue
+		}
+		seq.Schema = convertSchemaName(seq.Schema, schema, target, overrides)
+		seq.DataType = ""
+		converted.Sequences = append(converted.Sequences, seq)
+	}
+
+	// La definición de las vistas está en el SQL del motor de origen
+	for _, view := range schema.Views {
+		report.addIssue(Table{Schema: view.Schema, TableName: view.ViewName}, "", "", "", "warning",
+			"la vista no se convierte; su definición debe reescribirse para el dialecto destino")
+	}
+	for _, routine := range schema.Routines {
+		report.addIssue(Table{Schema: routine.Schema, TableName: routine.RoutineName}, "", "", "", "warning",
+			fmt.Sprintf("la rutina (%s) no se convierte; su código debe reescribirse para el dialecto destino", routine.Kind))
+	}
+
+	return converted, report, nil
+}
+
+func isConversionSource(dbType string) bool {
+	for _, s := range conversionSources {
+		if s == dbType {
+			return true
+		}
+	}
+	return false
+}
+
+func isCascadingAction(action string) bool {
+	switch strings.ToUpper(action) {
+	case "CASCADE", "SET NULL", "SET DEFAULT":
+		return true
+	}
+	return false
+}
+
+// El schema por defecto del origen pasa a ser el del destino (dbo ↔ public)
+// salvo que las reglas indiquen otra cosa. En MySQL el schema es la propia
+// base de datos.
+func convertSchemaName(schemaName string, schema *DatabaseSchema, target string, overrides *TypeOverrides) string {
+	if mapped, ok := overrides.Schemas[schemaName]; ok {
+		return mapped
+	}
+
+	sourceDefault := defaultSchemaFor(schema.DBType)
+	if schema.DBType == "mysql" {
+		sourceDefault = schema.DatabaseName
+	}
+
+	if schemaName != "" && schemaName == sourceDefault && defaultSchemaFor(target) != "" {
+		return defaultSchemaFor(target)
+	}
+	return schemaName
+}
+
+func defaultSchemaFor(dbType string) string {
+	switch dbType {
+	case "sqlserver", "sybase":
+		return "dbo"
+	case "postgres":
+		return "public"
+	case "sqlite":
+		return sqliteSchema
+	default:
+		return ""
+	}
+}
+
+func (r *ConversionReport) addIssue(table Table, column, sourceType, targetType, severity, reason string) {
+	r.Issues = append(r.Issues, ConversionIssue{
+		Schema:     table.Schema,
+		Table:      table.TableName,
+		Column:     column,
+		SourceType: sourceType,
+		TargetType: targetType,
+		Severity:   severity,
+		Reason:     reason,
+	})
+}
+
+func convertColumn(col Column, table Table, source, target string, overrides *TypeOverrides, report *ConversionReport) Column {
+	ct, notes := toCanonicalType(col, source)
+
+	newCol := Column{
+		ColumnName:   col.ColumnName,
+		IsNullable:   col.IsNullable,
+		IsPrimaryKey: col.IsPrimaryKey,
+		IsIdentity:   col.IsIdentity,
+		Description:  col.Description,
+	}
+
+	// Las reglas por columna tienen prioridad sobre las reglas por tipo
+	spec, override := findTypeOverride(col, table, overrides)
+	if override {
+		applyTypeSpec(&newCol, spec, target)
+		notes = []conversionNote{{"info", "tipo definido por las reglas de conversión"}}
+	} else {
+		notes = append(notes, fromCanonicalType(ct, &newCol, target)...)
+	}
+
+	if newCol.IsIdentity {
+		// Se conservan semilla e incremento; la generación ALWAYS solo se
+		// mantiene entre motores que la interpretan igual
+		if col.Identity != nil {
+			newCol.Identity = &IdentityInfo{Seed: col.Identity.Seed, Increment: col.Identity.Increment}
+			if col.Identity.Generation == "ALWAYS" && source == "postgres" {
+				newCol.Identity.Generation = "ALWAYS"
+			}
+		}
+		notes = append(notes, convertIdentity(&newCol, target)...)
+	}
+
+	def, note := convertDefaultValue(col, ct, source, target)
+	newCol.DefaultValue = def
+	if def != "" {
+		newCol.DefaultConstraint = col.DefaultConstraint
+	}
+	if note != nil {
+		notes = append(notes, *note)
+	}
+
+	sourceType := describeColumnType(col, source)
+	targetType := describeColumnType(newCol, target)
+
+	report.Columns = append(report.Columns, ColumnConversion{
+		Schema:     table.Schema,
+		Table:      table.TableName,
+		Column:     col.ColumnName,
+		SourceType: sourceType,
+		TargetType: targetType,
+		Canonical:  ct.String(),
+		Override:   override,
+	})
+
+	for _, n := range notes {
+		report.addIssue(table, col.ColumnName, sourceType, targetType, n.Severity, n.Reason)
+	}
+
+	return newCol
+}
+
+func findTypeOverride(col Column, table Table, overrides *TypeOverrides) (string, bool) {
+	keys := []string{
+		table.Schema + "." + table.TableName + "." + col.ColumnName,
+		table.TableName + "." + col.ColumnName,
+	}
+	for _, key := range keys {
+		if spec, ok := overrides.Columns[strings.ToLower(key)]; ok {
+			return spec, true
+		}
+	}
+
+	spec, ok := overrides.Types[strings.ToLower(col.DataType)]
+	return spec, ok
+}
+
+// Aplica un tipo escrito como en el DDL, p. ej. "varchar(50)", "numeric(19,4)"
+// o "nvarchar(max)"
+func applyTypeSpec(col *Column, spec, target string) {
+	match := typeSpecPattern.FindStringSubmatch(spec)
+	if match == nil {
+		col.DataType = spec
+		return
+	}
+
+	col.DataType = strings.ToLower(match[1])
+	col.IsUnsigned = match[4] != ""
+
+	switch {
+	case match[2] == "max":
+		col.MaxLength = -1
+	case match[3] != "":
+		col.Precision, _ = strconv.Atoi(match[2])
+		col.Scale, _ = strconv.Atoi(match[3])
+	case match[2] != "":
+		n, _ := strconv.Atoi(match[2])
+		switch {
+		case hasLengthArgument(col.DataType, target):
+			col.MaxLength = n
+			// Sybase guarda la longitud de unichar/univarchar en bytes
+			if target == "sybase" && strings.HasPrefix(col.DataType, "uni") {
+				col.MaxLength = n * 2
+			}
+		case hasFractionalSeconds(col.DataType, target):
+			setDatetimePrecision(col, n)
+		default:
+			col.Precision = n
+		}
+	}
+}
+
+func (ct canonicalType) String() string {
+	name := ct.Kind
+	if ct.Unicode {
+		name = "n" + name
+	}
+
+	switch {
+	case ct.Kind == "decimal" || ct.Kind == "money":
+		if ct.Precision > 0 {
+			name = fmt.Sprintf("%s(%d,%d)", name, ct.Precision, ct.Scale)
+		}
+	case ct.Length > 0:
+		name = fmt.Sprintf("%s(%d)", name, ct.Length)
+	case ct.Kind == "time" || ct.Kind == "datetime" || ct.Kind == "timestamptz":
+		name = fmt.Sprintf("%s(%d)", name, ct.FractionalSeconds)
+	}
+
+	if ct.Unsigned {
+		name += " unsigned"
+	}
+	return name
+}
+
+// Traduce el tipo de origen al tipo canónico. Las notas describen cambios de
+// comportamiento que dependen del motor de origen.
+func toCanonicalType(col Column, dialect string) (canonicalType, []conversionNote) {
+	dataType := strings.ToLower(strings.TrimSpace(col.DataType))
+	ct := canonicalType{Length: col.MaxLength, Precision: col.Precision, Scale: col.Scale, Unsigned: col.IsUnsigned}
+	var notes []conversionNote
+
+	switch dialect {
+	case "oracle":
+		if kind, ok := oracleCanonicalType(col, dataType, &ct, &notes); ok {
+			ct.Kind = kind
+			return ct, notes
+		}
+	case "sybase":
+		// Sybase ASE 15 tiene enteros sin signo como tipos propios
+		if strings.HasPrefix(dataType, "unsigned ") {
+			ct.Unsigned = true
+			dataType = strings.TrimPrefix(dataType, "unsigned ")
+		}
+		// La longitud de unichar/univarchar se guarda en bytes
+		if strings.HasPrefix(dataType, "uni") && ct.Length > 0 {
+			ct.Length = ct.Length / 2
+		}
+	}
+
+	switch dataType {
+	case "bit":
+		// En SQL Server y Sybase bit es un booleano; en MySQL y PostgreSQL es
+		// una cadena de bits
+		switch dialect {
+		case "mysql":
+			ct.Kind, ct.Length = "bit", col.Precision
+		case "postgres":
+			ct.Kind = "bit"
+		default:
+			ct.Kind, ct.Length = "boolean", 0
+		}
+	case "bit varying", "varbit":
+		ct.Kind = "bit"
+	case "boolean", "bool":
+		ct.Kind = "boolean"
+	case "tinyint":
+		ct.Kind = "tinyint"
+		// El tinyint de SQL Server y Sybase va de 0 a 255
+		if dialect == "sqlserver" || dialect == "sybase" {
+			ct.Unsigned = true
+		}
+	case "smallint", "int2":
+		ct.Kind = "smallint"
+	case "mediumint", "int", "integer", "int4":
+		ct.Kind = "integer"
+		// En SQLite INTEGER es un entero de 64 bits
+		if dialect == "sqlite" {
+			ct.Kind = "bigint"
+		}
+	case "bigint", "int8":
+		ct.Kind = "bigint"
+	case "year":
+		ct.Kind = "smallint"
+		notes = append(notes, conversionNote{"info", "YEAR se convierte en un entero"})
+	case "decimal", "numeric", "dec":
+		ct.Kind = "decimal"
+	case "money":
+		ct.Kind, ct.Precision, ct.Scale = "money", 19, 4
+	case "smallmoney":
+		ct.Kind, ct.Precision, ct.Scale = "money", 10, 4
+	case "real", "float4":
+		ct.Kind = "float"
+	case "float":
+		// FLOAT de MySQL es de precisión simple; en SQL Server y Sybase la
+		// precisión (en bits) decide si es simple o doble
+		ct.Kind = "double"
+		if dialect == "mysql" || (col.Precision > 0 && col.Precision <= 24) {
+			ct.Kind = "float"
+		}
+	case "double", "double precision", "float8":
+		ct.Kind = "double"
+	case "char", "character", "bpchar":
+		ct.Kind = "char"
+	case "nchar", "unichar":
+		ct.Kind, ct.Unicode = "char", true
+	case "varchar", "character varying", "sysname":
+		ct.Kind = "varchar"
+	case "nvarchar", "univarchar":
+		ct.Kind, ct.Unicode = "varchar", true
+	case "text", "tinytext", "mediumtext", "longtext", "clob":
+		ct.Kind, ct.Length = "text", 0
+	case "ntext", "unitext":
+		ct.Kind, ct.Length, ct.Unicode = "text", 0, true
+	case "citext":
+		ct.Kind, ct.Length = "text", 0
+		notes = append(notes, conversionNote{"warning", "se pierde la comparación sin distinguir mayúsculas de citext"})
+	case "binary":
+		ct.Kind = "binary"
+	case "varbinary":
+		ct.Kind = "varbinary"
+	case "image", "blob", "tinyblob", "mediumblob", "longblob", "bytea":
+		ct.Kind, ct.Length = "blob", 0
+	case "date":
+		ct.Kind = "date"
+	case "time", "time without time zone":
+		ct.Kind = "time"
+		ct.FractionalSeconds = defaultFractionalSeconds(dialect, col.DatetimePrecision)
+	case "time with time zone", "timetz":
+		ct.Kind, ct.FractionalSeconds = "time", defaultFractionalSeconds(dialect, col.DatetimePrecision)
+		notes = append(notes, conversionNote{"lossy", "se pierde la zona horaria de la hora"})
+	case "bigtime":
+		ct.Kind, ct.FractionalSeconds = "time", 6
+	case "datetime":
+		ct.Kind = "datetime"
+		// El datetime de SQL Server y Sybase tiene una precisión de 1/300 s
+		ct.FractionalSeconds, ct.ApproximateMillis = 3, true
+		if dialect == "mysql" || dialect == "sqlite" {
+			ct.FractionalSeconds, ct.ApproximateMillis = defaultFractionalSeconds(dialect, col.DatetimePrecision), false
+		}
+	case "smalldatetime":
+		ct.Kind, ct.FractionalSeconds = "datetime", 0
+	case "datetime2":
+		ct.Kind = "datetime"
+		ct.FractionalSeconds = defaultFractionalSeconds(dialect, col.DatetimePrecision)
+	case "bigdatetime":
+		ct.Kind, ct.FractionalSeconds = "datetime", 6
+	case "timestamp", "timestamp without time zone":
+		switch dialect {
+		case "sqlserver", "sybase":
+			// timestamp es un sinónimo de rowversion, no una fecha
+			ct.Kind, ct.Length = "rowversion", 8
+		case "mysql":
+			// TIMESTAMP de MySQL se guarda en UTC y se convierte según la sesión
+			ct.Kind, ct.FractionalSeconds = "timestamptz", defaultFractionalSeconds(dialect, col.DatetimePrecision)
+		case "postgres":
+			ct.Kind, ct.FractionalSeconds = "datetime", defaultFractionalSeconds(dialect, col.DatetimePrecision)
+		default:
+			ct.Kind, ct.FractionalSeconds = "datetime", 6
+		}
+	case "rowversion":
+		ct.Kind, ct.Length = "rowversion", 8
+	case "datetimeoffset":
+		ct.Kind = "timestamptz"
+		ct.FractionalSeconds = defaultFractionalSeconds(dialect, col.DatetimePrecision)
+	case "timestamp with time zone", "timestamptz":
+		ct.Kind, ct.FractionalSeconds = "timestamptz", defaultFractionalSeconds(dialect, col.DatetimePrecision)
+	case "interval":
+		ct.Kind = "interval"
+	case "uniqueidentifier", "uuid":
+		ct.Kind, ct.Length = "uuid", 0
+	case "json", "jsonb":
+		ct.Kind = "json"
+	case "xml":
+		ct.Kind = "xml"
+	case "enum", "set", "user-defined":
+		ct.Kind = "enum"
+		if ct.Length <= 0 {
+			ct.Length = 255
+		}
+		notes = append(notes, conversionNote{"warning", "se pierde la lista de valores permitidos; se guarda como texto"})
+	case "array":
+		ct.Kind = "array"
+	default:
+		if dialect == "sqlite" {
+			ct.Kind = sqliteAffinityKind(dataType, &notes)
+		} else {
+			ct.Kind, ct.SourceName = "unknown", dataType
+		}
+	}
+
+	// Longitud -1 (max) o sin longitud declarada significa sin límite
+	switch ct.Kind {
+	case "char", "varchar":
+		if ct.Length <= 0 {
+			ct.Kind, ct.Length = "text", 0
+		}
+	case "varbinary", "binary":
+		if ct.Length <= 0 {
+			ct.Kind, ct.Length = "blob", 0
+		}
+	}
+
+	// Los tipos LOB antiguos de SQL Server y Sybase tienen restricciones que
+	// el tipo destino no comparte
+	if (dialect == "sqlserver" || dialect == "sybase") &&
+		(dataType == "text" || dataType == "ntext" || dataType == "unitext" || dataType == "image") {
+		notes = append(notes, conversionNote{"info", "tipo LOB obsoleto (text/image); revisar código que use READTEXT/WRITETEXT o textptr"})
+	}
+
+	return ct, notes
+}
+
+// Precisión de fracción de segundo declarada, o la del motor cuando el
+// catálogo no la informa
+func defaultFractionalSeconds(dialect string, precision *int) int {
+	if precision != nil {
+		return *precision
+	}
+	switch dialect {
+	case "sqlserver":
+		return 7
+	case "postgres":
+		return 6
+	case "sybase":
+		return 3
+	default:
+		return 0
+	}
+}
+
+func oracleCanonicalType(col Column, dataType string, ct *canonicalType, notes *[]conversionNote) (string, bool) {
+	switch {
+	case dataType == "number" && col.PrecisionUnspecified && col.Scale == 0:
+		// NUMBER(*,0) es el INTEGER de Oracle
+		ct.Precision = 0
+		*notes = append(*notes, conversionNote{"lossy", "NUMBER(*,0) admite hasta 38 dígitos y bigint hasta 19"})
+		return "bigint", true
+	case dataType == "number":
+		return "decimal", true
+	case dataType == "float":
+		// La precisión de FLOAT en Oracle se expresa en bits (hasta 126)
+		if ct.Precision > 0 && ct.Precision <= 24 {
+			return "float", true
+		}
+		if ct.Precision > 53 {
+			*notes = append(*notes, conversionNote{"lossy", "FLOAT de Oracle admite más precisión que double"})
+		}
+		return "double", true
+	case dataType == "binary_float":
+		return "float", true
+	case dataType == "binary_double":
+		return "double", true
+	case dataType == "varchar2" || dataType == "varchar":
+		return "varchar", true
+	case dataType == "nvarchar2":
+		ct.Unicode = true
+		return "varchar", true
+	case dataType == "char":
+		return "char", true
+	case dataType == "nchar":
+		ct.Unicode = true
+		return "char", true
+	case dataType == "clob" || dataType == "long":
+		ct.Length = 0
+		return "text", true
+	case dataType == "nclob":
+		ct.Length, ct.Unicode = 0, true
+		return "text", true
+	case dataType == "blob" || dataType == "long raw":
+		ct.Length = 0
+		return "blob", true
+	case dataType == "raw":
+		return "varbinary", true
+	case dataType == "date":
+		// DATE de Oracle incluye la hora hasta el segundo
+		ct.FractionalSeconds = 0
+		*notes = append(*notes, conversionNote{"info", "DATE de Oracle incluye la hora; se convierte en fecha y hora"})
+		return "datetime", true
+	case strings.HasPrefix(dataType, "timestamp"):
+		ct.FractionalSeconds, ct.Scale, ct.Precision = 6, 0, 0
+		if col.DatetimePrecision != nil {
+			ct.FractionalSeconds = *col.DatetimePrecision
+		}
+		if strings.Contains(dataType, "time zone") {
+			if strings.Contains(dataType, "local time zone") {
+				*notes = append(*notes, conversionNote{"warning", "TIMESTAMP WITH LOCAL TIME ZONE se normaliza a la zona de la sesión"})
+			}
+			return "timestamptz", true
+		}
+		return "datetime", true
+	case strings.HasPrefix(dataType, "interval"):
+		return "interval", true
+	case dataType == "rowid" || dataType == "urowid":
+		ct.Length = 18
+		*notes = append(*notes, conversionNote{"warning", "ROWID no tiene equivalente; se guarda como texto"})
+		return "varchar", true
+	case dataType == "xmltype":
+		return "xml", true
+	}
+	return "", false
+}
+
+// Reglas de afinidad de SQLite para tipos declarados que no son estándar
+func sqliteAffinityKind(dataType string, notes *[]conversionNote) string {
+	switch {
+	case strings.Contains(dataType, "int"):
+		return "bigint"
+	case strings.Contains(dataType, "char") || strings.Contains(dataType, "clob") || strings.Contains(dataType, "text"):
+		return "text"
+	case dataType == "" || strings.Contains(dataType, "blob"):
+		if dataType == "" {
+			*notes = append(*notes, conversionNote{"warning", "columna sin tipo declarado; puede contener valores de cualquier tipo"})
+		}
+		return "blob"
+	case strings.Contains(dataType, "real") || strings.Contains(dataType, "floa") || strings.Contains(dataType, "doub"):
+		return "double"
+	default:
+		return "decimal"
+	}
+}
+
+// Límites de longitud de los tipos de texto y binarios por dialecto. En
+// Sybase dependen del tamaño de página; se asumen páginas de 16K.
+func maxTypeLength(kind, target string, unicode bool) int {
+	switch target {
+	case "sqlserver":
+		if unicode {
+			return 4000
+		}
+		return 8000
+	case "sybase":
+		return 16384
+	case "mysql":
+		switch kind {
+		case "char", "binary":
+			return 255
+		case "varbinary":
+			return 65535
+		}
+		// Límite de fila de 65535 bytes con utf8mb4
+		return 16383
+	default:
+		return 10485760
+	}
+}
+
+// Elige el tipo del dialecto destino para el tipo canónico y lo escribe en
+// col. Devuelve las notas sobre pérdidas o cambios de comportamiento.
+func fromCanonicalType(ct canonicalType, col *Column, target string) []conversionNote {
+	var notes []conversionNote
+	lossy := func(reason string) { notes = append(notes, conversionNote{"lossy", reason}) }
+	warn := func(reason string) { notes = append(notes, conversionNote{"warning", reason}) }
+	info := func(reason string) { notes = append(notes, conversionNote{"info", reason}) }
+
+	switch ct.Kind {
+	case "boolean":
+		switch target {
+		case "sqlserver":
+			col.DataType = "bit"
+		case "sybase":
+			// Las columnas bit de Sybase no admiten NULL
+			col.DataType = "bit"
+			if col.IsNullable != "NO" {
+				col.DataType = "tinyint"
+				warn("bit de Sybase no admite NULL; se usa tinyint")
+			}
+		case "mysql":
+			col.DataType = "tinyint"
+		case "postgres":
+			col.DataType = "boolean"
+		}
+
+	case "tinyint", "smallint", "integer", "bigint":
+		convertIntegerType(ct, col, target, warn)
+
+	case "decimal":
+		col.DataType = "decimal"
+		if target == "sybase" || target == "postgres" {
+			col.DataType = "numeric"
+		}
+		col.Precision, col.Scale = ct.Precision, ct.Scale
+
+		maxPrecision, maxScale := 38, 38
+		switch target {
+		case "mysql":
+			maxPrecision, maxScale = 65, 30
+		case "postgres":
+			maxPrecision, maxScale = 1000, 1000
+		}
+
+		switch {
+		case ct.Precision == 0 && target != "postgres":
+			// Sin precisión declarada (NUMBER de Oracle, numeric de PostgreSQL)
+			col.Precision, col.Scale = 38, 10
+			lossy("número sin precisión declarada; se usa precisión 38 y escala 10")
+		case ct.Precision > maxPrecision:
+			col.Precision = maxPrecision
+			lossy(fmt.Sprintf("la precisión %d supera el máximo de %d", ct.Precision, maxPrecision))
+		}
+		if col.Scale > maxScale {
+			col.Scale = maxScale
+			lossy(fmt.Sprintf("la escala %d supera el máximo de %d", ct.Scale, maxScale))
+		}
+
+	case "money":
+		switch target {
+		case "sqlserver", "sybase":
+			col.DataType = "money"
+			if ct.Precision == 10 {
+				col.DataType = "smallmoney"
+			}
+		case "mysql":
+			col.DataType, col.Precision, col.Scale = "decimal", ct.Precision, ct.Scale
+			warn("MySQL no tiene tipo monetario; se usa decimal con 4 decimales")
+		case "postgres":
+			// El money de PostgreSQL depende de lc_monetary y redondea a 2 decimales
+			col.DataType, col.Precision, col.Scale = "numeric", ct.Precision, ct.Scale
+			warn("se usa numeric en lugar de money de PostgreSQL (depende de lc_monetary y usa 2 decimales)")
+		}
+
+	case "float":
+		col.DataType = "real"
+		if target == "mysql" {
+			col.DataType = "float"
+		}
+
+	case "double":
+		switch target {
+		case "sqlserver":
+			col.DataType = "float"
+		case "mysql":
+			col.DataType = "double"
+		default:
+			col.DataType = "double precision"
+		}
+
+	case "char", "varchar":
+		convertCharacterType(ct, col, target, lossy, warn)
+
+	case "text", "enum":
+		if ct.Kind == "enum" {
+			convertCharacterType(canonicalType{Kind: "varchar", Length: ct.Length, Unicode: true}, col, target, lossy, warn)
+			break
+		}
+		switch target {
+		case "sqlserver":
+			col.DataType, col.MaxLength = "varchar", -1
+			if ct.Unicode {
+				col.DataType = "nvarchar"
+			}
+		case "sybase":
+			col.DataType = "text"
+			if ct.Unicode {
+				col.DataType = "unitext"
+			}
+			warn("las columnas text de Sybase no admiten índices ni comparaciones directas")
+		case "mysql":
+			col.DataType = "longtext"
+		case "postgres":
+			col.DataType = "text"
+		}
+
+	case "binary", "varbinary":
+		if target == "postgres" {
+			col.DataType = "bytea"
+			info("bytea no limita la longitud ni rellena valores de longitud fija")
+			break
+		}
+		col.DataType, col.MaxLength = ct.Kind, ct.Length
+		if ct.Length > maxTypeLength(ct.Kind, target, false) {
+			switch target {
+			case "sqlserver":
+				col.DataType, col.MaxLength = "varbinary", -1
+			case "sybase":
+				col.DataType, col.MaxLength = "image", 0
+				warn("la longitud supera el máximo de Sybase; se usa image")
+			case "mysql":
+				col.DataType, col.MaxLength = "longblob", 0
+			}
+		}
+
+	case "blob":
+		switch target {
+		case "sqlserver":
+			col.DataType, col.MaxLength = "varbinary", -1
+		case "sybase":
+			col.DataType = "image"
+			warn("las columnas image de Sybase no admiten índices")
+		case "mysql":
+			col.DataType = "longblob"
+		case "postgres":
+			col.DataType = "bytea"
+		}
+
+	case "bit":
+		length := ct.Length
+		if length <= 0 {
+			length = 1
+		}
+		switch target {
+		case "sqlserver", "sybase":
+			if length == 1 {
+				col.DataType = "bit"
+			} else {
+				col.DataType, col.MaxLength = "binary", (length+7)/8
+				warn("la cadena de bits se guarda como binary")
+			}
+		default:
+			col.DataType, col.MaxLength = "bit", length
+		}
+
+	case "date":
+		col.DataType = "date"
+
+	case "time":
+		convertTimeType(ct, col, target, lossy)
+
+	case "datetime":
+		convertDateTimeType(ct, col, target, lossy)
+
+	case "timestamptz":
+		switch target {
+		case "sqlserver":
+			col.DataType = "datetimeoffset"
+			setDatetimePrecision(col, fractionalScale(ct.FractionalSeconds, 7, lossy))
+		case "sybase":
+			col.DataType = "bigdatetime"
+			lossy("Sybase no guarda la zona horaria; se pierde el desplazamiento")
+		case "mysql":
+			col.DataType = "timestamp"
+			setDatetimePrecision(col, fractionalScale(ct.FractionalSeconds, 6, lossy))
+			warn("TIMESTAMP de MySQL se convierte a UTC según la zona de la sesión y solo admite fechas hasta 2038")
+		case "postgres":
+			col.DataType = "timestamp with time zone"
+			setDatetimePrecision(col, fractionalScale(ct.FractionalSeconds, 6, lossy))
+		}
+
+	case "interval":
+		if target == "postgres" {
+			col.DataType = "interval"
+			break
+		}
+		col.DataType, col.MaxLength = "varchar", 64
+		warn("INTERVAL no tiene equivalente; se guarda como texto")
+
+	case "rowversion":
+		switch target {
+		case "sqlserver":
+			col.DataType = "rowversion"
+		case "sybase":
+			col.DataType = "timestamp"
+		case "mysql":
+			col.DataType, col.MaxLength = "binary", 8
+			warn("se pierde la actualización automática de la versión de fila")
+		case "postgres":
+			col.DataType = "bytea"
+			warn("se pierde la actualización automática de la versión de fila")
+		}
+
+	case "uuid":
+		switch target {
+		case "sqlserver":
+			col.DataType = "uniqueidentifier"
+		case "postgres":
+			col.DataType = "uuid"
+		default:
+			col.DataType, col.MaxLength = "char", 36
+			info("el identificador único se guarda como texto de 36 caracteres")
+		}
+
+	case "json":
+		switch target {
+		case "sqlserver":
+			col.DataType, col.MaxLength = "nvarchar", -1
+			warn("se pierde la validación de JSON")
+		case "sybase":
+			col.DataType = "text"
+			warn("se pierde la validación de JSON")
+		default:
+			col.DataType = "json"
+		}
+
+	case "xml":
+		switch target {
+		case "sqlserver", "postgres":
+			col.DataType = "xml"
+		case "sybase":
+			col.DataType = "text"
+			warn("se pierde la validación de XML")
+		case "mysql":
+			col.DataType = "longtext"
+			warn("se pierde la validación de XML")
+		}
+
+	case "array":
+		switch target {
+		case "sqlserver":
+			col.DataType, col.MaxLength = "nvarchar", -1
+		case "sybase":
+			col.DataType = "text"
+		case "mysql":
+			col.DataType = "json"
+		case "postgres":
+			col.DataType = "text"
+		}
+		lossy("el arreglo se serializa como texto; se pierde el tipo de los elementos")
+
+	default:
+		col.DataType = ct.SourceName
+		col.MaxLength, col.Precision, col.Scale = ct.Length, ct.Precision, ct.Scale
+		warn("tipo sin equivalente conocido; se conserva el tipo de origen")
+	}
+
+	return notes
+}
+
+func convertIntegerType(ct canonicalType, col *Column, target string, warn func(string)) {
+	intNames := map[string]string{"tinyint": "tinyint", "smallint": "smallint", "integer": "int", "bigint": "bigint"}
+	if target == "postgres" {
+		intNames["integer"] = "integer"
+	}
+
+	kind := ct.Kind
+
+	switch target {
+	case "mysql":
+		// MySQL tiene versiones sin signo de todos los enteros
+		col.DataType, col.IsUnsigned = intNames[kind], ct.Unsigned
+		return
+	case "sybase":
+		// Sybase ASE 15 tiene enteros sin signo salvo tinyint, que ya lo es
+		if ct.Unsigned && kind != "tinyint" {
+			col.DataType = "unsigned " + intNames[kind]
+			return
+		}
+		if kind == "tinyint" && !ct.Unsigned {
+			kind = "smallint"
+		}
+		col.DataType = intNames[kind]
+		return
+	case "sqlserver":
+		// El tinyint de SQL Server es sin signo; un tinyint con signo no cabe
+		if kind == "tinyint" && ct.Unsigned {
+			col.DataType = "tinyint"
+			return
+		}
+		if kind == "tinyint" {
+			kind = "smallint"
+		}
+	case "postgres":
+		if kind == "tinyint" {
+			kind = "smallint"
+			ct.Unsigned = false
+		}
+	}
+
+	// Un entero sin signo necesita el tipo inmediatamente superior
+	if ct.Unsigned {
+		switch kind {
+		case "smallint":
+			kind = "integer"
+		case "integer":
+			kind = "bigint"
+		case "bigint":
+			col.DataType, col.Precision, col.Scale = "numeric", 20, 0
+			if target == "sqlserver" {
+				col.DataType = "decimal"
+			}
+			warn("bigint sin signo no cabe en bigint; se usa un decimal de 20 dígitos")
+			return
+		}
+	}
+
+	col.DataType = intNames[kind]
+}
+
+func convertCharacterType(ct canonicalType, col *Column, target string, lossy, warn func(string)) {
+	limit := maxTypeLength(ct.Kind, target, ct.Unicode)
+	length := ct.Length
+
+	switch target {
+	case "sqlserver":
+		prefix := ""
+		if ct.Unicode {
+			prefix = "n"
+		}
+		col.DataType, col.MaxLength = prefix+ct.Kind, length
+		if length > limit {
+			col.DataType, col.MaxLength = prefix+"varchar", -1
+			if ct.Kind == "char" {
+				warn("la longitud supera el máximo de char; se usa varchar(max) sin relleno")
+			}
+		}
+	case "sybase":
+		col.DataType = ct.Kind
+		col.MaxLength = length
+		if ct.Unicode {
+			col.DataType = "uni" + ct.Kind
+			col.MaxLength = length * 2
+		}
+		if length > limit {
+			col.DataType, col.MaxLength = "text", 0
+			if ct.Unicode {
+				col.DataType = "unitext"
+			}
+			warn("la longitud supera el máximo de Sybase; se usa text, que no admite índices")
+		}
+	case "mysql":
+		col.DataType, col.MaxLength = ct.Kind, length
+		if ct.Kind == "char" && length > limit {
+			col.DataType = "varchar"
+			warn("la longitud supera el máximo de char en MySQL; se usa varchar sin relleno")
+		}
+		if length > maxTypeLength("varchar", target, ct.Unicode) {
+			col.DataType, col.MaxLength = "mediumtext", 0
+			warn("la longitud supera el máximo de varchar en MySQL; se usa mediumtext")
+		}
+	case "postgres":
+		col.DataType, col.MaxLength = "character varying", length
+		if ct.Kind == "char" {
+			col.DataType = "character"
+		}
+		if length > limit {
+			col.DataType, col.MaxLength = "text", 0
+		}
+	}
+}
+
+func convertTimeType(ct canonicalType, col *Column, target string, lossy func(string)) {
+	switch target {
+	case "sqlserver":
+		col.DataType = "time"
+		setDatetimePrecision(col, fractionalScale(ct.FractionalSeconds, 7, lossy))
+	case "sybase":
+		col.DataType = "time"
+		if ct.FractionalSeconds > 3 {
+			col.DataType = "bigtime"
+			fractionalScale(ct.FractionalSeconds, 6, lossy)
+		}
+	case "mysql":
+		col.DataType = "time"
+		setDatetimePrecision(col, fractionalScale(ct.FractionalSeconds, 6, lossy))
+	case "postgres":
+		col.DataType = "time without time zone"
+		setDatetimePrecision(col, fractionalScale(ct.FractionalSeconds, 6, lossy))
+	}
+}
+
+func convertDateTimeType(ct canonicalType, col *Column, target string, lossy func(string)) {
+	switch target {
+	case "sqlserver":
+		col.DataType = "datetime2"
+		setDatetimePrecision(col, fractionalScale(ct.FractionalSeconds, 7, lossy))
+	case "sybase":
+		col.DataType = "datetime"
+		switch {
+		case ct.FractionalSeconds > 3:
+			col.DataType = "bigdatetime"
+			fractionalScale(ct.FractionalSeconds, 6, lossy)
+		case ct.FractionalSeconds > 0 && !ct.ApproximateMillis:
+			// Solo es exacto si el origen ya redondeaba a 1/300 s
+			lossy("datetime de Sybase redondea los milisegundos a 1/300 s")
+		}
+	case "mysql":
+		col.DataType = "datetime"
+		setDatetimePrecision(col, fractionalScale(ct.FractionalSeconds, 6, lossy))
+	case "postgres":
+		col.DataType = "timestamp without time zone"
+		setDatetimePrecision(col, fractionalScale(ct.FractionalSeconds, 6, lossy))
+	}
+}
+
+// Devuelve los dígitos de fracción de segundo a declarar, limitados al
+// máximo del destino
+func fractionalScale(digits, limit int, lossy func(string)) int {
+	if digits > limit {
+		lossy(fmt.Sprintf("la fracción de segundo se reduce de %d a %d dígitos", digits, limit))
+		return limit
+	}
+	return digits
+}
+
+// Las columnas identity de PostgreSQL y MySQL deben ser enteras; Sybase y
+// SQL Server también admiten numeric(p,0)
+func convertIdentity(col *Column, target string) []conversionNote {
+	var notes []conversionNote
+
+	if (target == "postgres" || target == "mysql") && isDecimalType(col.DataType) {
+		switch {
+		case col.Precision > 0 && col.Precision <= 9:
+			col.DataType = "integer"
+			if target == "mysql" {
+				col.DataType = "int"
+			}
+		default:
+			col.DataType = "bigint"
+			if col.Precision == 0 || col.Precision > 18 {
+				notes = append(notes, conversionNote{"lossy", "la identidad numérica supera el rango de bigint"})
+			}
+		}
+		col.Precision, col.Scale = 0, 0
+		notes = append(notes, conversionNote{"info", "la identidad numeric(p,0) se convierte en un entero"})
+	}
+
+	switch target {
+	case "postgres":
+		notes = append(notes, conversionNote{"info", "tras cargar los datos hay que ajustar la secuencia de identidad con setval"})
+	case "sqlserver":
+		notes = append(notes, conversionNote{"info", "la carga de datos requiere SET IDENTITY_INSERT ON y DBCC CHECKIDENT"})
+	case "sybase":
+		notes = append(notes, conversionNote{"info", "la carga de datos requiere set identity_insert on; Sybase puede dejar saltos en la identidad"})
+	case "mysql":
+		if !col.IsPrimaryKey {
+			notes = append(notes, conversionNote{"warning", "AUTO_INCREMENT requiere que la columna encabece un índice"})
+		}
+	}
+
+	return notes
+}
+
+// Traduce el valor por defecto: quita envolturas propias del origen y
+// traduce las funciones habituales de fecha actual, identificadores únicos
+// y booleanos. Otras expresiones se copian y se avisa para revisarlas.
+func convertDefaultValue(col Column, ct canonicalType, source, target string) (string, *conversionNote) {
+	def := strings.TrimSpace(col.DefaultValue)
+	if def == "" {
+		return "", nil
+	}
+
+	// La secuencia de una columna serial la reemplaza la identidad
+	if col.IsIdentity && strings.HasPrefix(strings.ToLower(def), "nextval(") {
+		return "", nil
+	}
+
+	switch source {
+	case "sqlserver":
+		// SQL Server guarda los valores entre paréntesis: ((0)), ('abc')
+		def = stripOuterParens(def)
+	case "postgres":
+		// PostgreSQL agrega conversiones explícitas: 'abc'::character varying
+		def = stripPostgresCast(def)
+	case "mysql":
+		if isTextualType(col.DataType) && !strings.HasPrefix(def, "'") && !isSQLExpression(def) {
+			def = quoteString(def)
+		}
+	}
+
+	lower := strings.ToLower(strings.TrimSpace(def))
+
+	switch lower {
+	case "getdate()", "current_timestamp", "current_timestamp()", "now()", "sysdate",
+		"systimestamp", "localtimestamp", "sysdatetime()", "datetime('now')":
+		if target == "sqlserver" || target == "sybase" {
+			return "getdate()", nil
+		}
+		return "CURRENT_TIMESTAMP", nil
+	case "newid()", "gen_random_uuid()", "uuid()", "uuid_generate_v4()", "sys_guid()":
+		switch target {
+		case "sqlserver":
+			return "newid()", nil
+		case "sybase":
+			// newid(1) devuelve el formato de 36 caracteres con guiones
+			return "newid(1)", nil
+		case "mysql":
+			return "(uuid())", nil
+		default:
+			return "gen_random_uuid()", nil
+		}
+	}
+
+	if ct.Kind == "boolean" {
+		switch strings.Trim(lower, "'") {
+		case "true", "1", "b'1'":
+			if target == "postgres" {
+				return "true", nil
+			}
+			return "1", nil
+		case "false", "0", "b'0'":
+			if target == "postgres" {
+				return "false", nil
+			}
+			return "0", nil
+		}
+	}
+
+	if strings.HasPrefix(def, "'") || numericLiteral.MatchString(def) || lower == "null" {
+		return def, nil
+	}
+
+	return def, &conversionNote{"warning", fmt.Sprintf("valor por defecto copiado sin traducir: %s", def)}
+}
+
+func stripOuterParens(value string) string {
+	for strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") && balancedParens(value[1:len(value)-1]) {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+	return value
+}
+
+// Verifica que los paréntesis estén equilibrados fuera de los literales
+func balancedParens(value string) bool {
+	depth := 0
+	inString := false
+	for _, r := range value {
+		switch {
+		case r == '\'':
+			inString = !inString
+		case inString:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+func stripPostgresCast(value string) string {
+	// Solo se quitan conversiones al final de un literal: 'abc'::text
+	if strings.HasPrefix(value, "'") {
+		if end := strings.LastIndex(value, "'::"); end > 0 {
+			return value[:end+1]
+		}
+	}
+	if strings.HasPrefix(value, "(") {
+		if end := strings.LastIndex(value, ")::"); end > 0 && balancedParens(value[1:end]) {
+			return stripOuterParens(value[:end+1])
+		}
+	}
+	return value
+}
+
+// Las propiedades de índice que el destino no soporta se informan pero se
+// conservan en el esquema
+func convertIndex(idx Index, table Table, target string, report *ConversionReport) Index {
+	if idx.FilterPredicate != "" && (target == "mysql" || target == "sybase") {
+		report.addIssue(table, "", "", "", "warning",
+			fmt.Sprintf("el índice %s es parcial (WHERE %s) y el destino no soporta índices filtrados", idx.IndexName, idx.FilterPredicate))
+	}
+	if len(idx.IncludedColumns) > 0 && target != "sqlserver" && target != "postgres" {
+		report.addIssue(table, "", "", "", "info",
+			fmt.Sprintf("el índice %s tiene columnas incluidas que el destino no soporta", idx.IndexName))
+	}
+
+	// El tipo de índice es propio de cada motor
+	idx.IndexType = ""
+	return idx
+}
+
+// Describe el tipo para el informe; para dialectos sin DDL se agrega la
+// longitud porque formatColumnType no la conoce
+func describeColumnType(col Column, dialect string) string {
+	desc := formatColumnType(col, dialect)
+	if !isDDLDialect(dialect) && desc == col.DataType && col.MaxLength > 0 {
+		desc = fmt.Sprintf("%s(%d)", col.DataType, col.MaxLength)
+	}
+	return desc
+}
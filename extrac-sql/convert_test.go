@@ -0,0 +1,168 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConvertSchema(t *testing.T) {
+	source := &DatabaseSchema{DBType: "sqlserver", Schema: "dbo", Tables: []Table{{
+		TableName: "clientes",
+		Schema:    "dbo",
+		Columns: []Column{
+			{ColumnName: "id", DataType: "int", Precision: 10, IsNullable: "NO", IsPrimaryKey: true, IsIdentity: true},
+			{ColumnName: "nombre", DataType: "nvarchar", MaxLength: 50, IsNullable: "YES"},
+			{ColumnName: "notas", DataType: "nvarchar", MaxLength: -1, IsNullable: "YES"},
+			{ColumnName: "activo", DataType: "bit", IsNullable: "NO", DefaultValue: "((0))"},
+			{ColumnName: "saldo", DataType: "money", Precision: 19, Scale: 4, IsNullable: "YES"},
+			{ColumnName: "codigo", DataType: "uniqueidentifier", IsNullable: "YES"},
+		},
+	}}}
+
+	type converted struct {
+		dataType     string
+		maxLength    int
+		precision    int
+		scale        int
+		defaultValue string
+	}
+
+	tests := []struct {
+		target    string
+		overrides *TypeOverrides
+		schema    string
+		want      map[string]converted
+	}{
+		{
+			target: "postgres",
+			schema: "public",
+			want: map[string]converted{
+				"id":     {dataType: "integer"},
+				"nombre": {dataType: "character varying", maxLength: 50},
+				"notas":  {dataType: "text"},
+				"activo": {dataType: "boolean", defaultValue: "false"},
+				"codigo": {dataType: "uuid"},
+			},
+		},
+		{
+			target:    "mysql",
+			overrides: &TypeOverrides{Types: map[string]string{"money": "numeric(12,2)"}, Schemas: map[string]string{"dbo": "ventas"}},
+			schema:    "ventas",
+			want: map[string]converted{
+				"id":     {dataType: "int"},
+				"nombre": {dataType: "varchar", maxLength: 50},
+				"notas":  {dataType: "longtext"},
+				"activo": {dataType: "tinyint", defaultValue: "0"},
+				"saldo":  {dataType: "numeric", precision: 12, scale: 2},
+				"codigo": {dataType: "char", maxLength: 36},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			result, report, err := convertSchema(source, tt.target, tt.overrides)
+			if err != nil {
+				t.Fatalf("convertSchema: %v", err)
+			}
+
+			if result.DBType != tt.target || report.TargetDBType != tt.target {
+				t.Errorf("dbType = %s, informe = %s, se esperaba %s", result.DBType, report.TargetDBType, tt.target)
+			}
+			if result.Tables[0].Schema != tt.schema {
+				t.Errorf("schema = %s, se esperaba %s", result.Tables[0].Schema, tt.schema)
+			}
+
+			columns := make(map[string]Column)
+			for _, col := range result.Tables[0].Columns {
+				columns[col.ColumnName] = col
+			}
+			for name, want := range tt.want {
+				col := columns[name]
+				got := converted{col.DataType, col.MaxLength, col.Precision, col.Scale, col.DefaultValue}
+				if got != want {
+					t.Errorf("%s = %+v, se esperaba %+v", name, got, want)
+				}
+			}
+
+			if id := columns["id"]; !id.IsIdentity || !id.IsPrimaryKey || id.IsNullable != "NO" {
+				t.Errorf("id pierde identity, clave primaria o NOT NULL: %+v", id)
+			}
+		})
+	}
+}
+
+// NUMBER(*,0) es un entero; NUMBER sin precisión ni escala admite decimales
+func TestConvertSchemaOracleNumber(t *testing.T) {
+	source := &DatabaseSchema{DBType: "oracle", Schema: "VENTAS", Tables: []Table{{
+		TableName: "PEDIDOS",
+		Schema:    "VENTAS",
+		Columns: []Column{
+			{ColumnName: "ID", DataType: "number", PrecisionUnspecified: true, IsNullable: "NO"},
+			{ColumnName: "IMPORTE", DataType: "number", Precision: 10, Scale: 2, IsNullable: "YES"},
+			{ColumnName: "FACTOR", DataType: "number", IsNullable: "YES"},
+		},
+	}}}
+
+	result, _, err := convertSchema(source, "postgres", nil)
+	if err != nil {
+		t.Fatalf("convertSchema: %v", err)
+	}
+
+	var got []string
+	for _, col := range result.Tables[0].Columns {
+		got = append(got, formatColumnType(col, "postgres"))
+	}
+	if want := []string{"bigint", "numeric(10,2)", "numeric"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tipos = %v, se esperaba %v", got, want)
+	}
+}
+
+// Los dígitos de fracción de segundo se conservan aunque sean 0; sin
+// declarar se usa la precisión por defecto del origen
+func TestConvertSchemaDatetimePrecision(t *testing.T) {
+	digits := func(value int) *int { return &value }
+	source := &DatabaseSchema{DBType: "sqlserver", Schema: "dbo", Tables: []Table{{
+		TableName: "eventos",
+		Schema:    "dbo",
+		Columns: []Column{
+			{ColumnName: "alta", DataType: "datetime2", DatetimePrecision: digits(0), IsNullable: "NO"},
+			{ColumnName: "baja", DataType: "datetime2", IsNullable: "YES"},
+			{ColumnName: "hora", DataType: "time", DatetimePrecision: digits(3), IsNullable: "YES"},
+		},
+	}}}
+
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"postgres", []string{"timestamp(0) without time zone", "timestamp(6) without time zone", "time(3) without time zone"}},
+		{"mysql", []string{"datetime(0)", "datetime(6)", "time(3)"}},
+	}
+
+	for _, tt := range tests {
+		result, _, err := convertSchema(source, tt.target, nil)
+		if err != nil {
+			t.Fatalf("convertSchema: %v", err)
+		}
+
+		var got []string
+		for _, col := range result.Tables[0].Columns {
+			got = append(got, formatColumnType(col, tt.target))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: tipos = %v, se esperaba %v", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestConvertSchemaUnsupported(t *testing.T) {
+	source := &DatabaseSchema{DBType: "sqlserver", Tables: []Table{}}
+
+	if _, _, err := convertSchema(source, "oracle", nil); err == nil {
+		t.Errorf("se esperaba un error para el dialecto destino oracle")
+	}
+	if _, _, err := convertSchema(&DatabaseSchema{DBType: "mongodb"}, "postgres", nil); err == nil {
+		t.Errorf("se esperaba un error para el origen mongodb")
+	}
+}
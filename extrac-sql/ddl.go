@@ -0,0 +1,375 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialectos para los que se puede generar DDL
+var ddlDialects = []string{"sqlserver", "sybase", "mysql", "postgres"}
+
+func isDDLDialect(dialect string) bool {
+	for _, d := range ddlDialects {
+		if d == dialect {
+			return true
+		}
+	}
+	return false
+}
+
+// Genera las sentencias CREATE TABLE para todas las tablas del esquema en el
+// dialecto indicado. Los tipos de datos se escriben tal como se extrajeron,
+// por lo que el dialecto debe coincidir con el motor de origen.
+func generateDDL(schema *DatabaseSchema, dialect string) (string, error) {
+	if !isDDLDialect(dialect) {
+		return "", fmt.Errorf("dialecto no soportado para DDL: %s (válidos: %s)", dialect, strings.Join(ddlDialects, ", "))
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "-- DDL generado para %s (%s)\n", schema.DatabaseName, dialect)
+	fmt.Fprintf(&sb, "-- Tablas: %d\n", len(schema.Tables))
+	if len(schema.Views) > 0 {
+		fmt.Fprintf(&sb, "-- Vistas: %d\n", len(schema.Views))
+	}
+	sb.WriteString("\n")
+
+	// Sybase solo acepta identificadores entre comillas dobles con esta opción
+	if dialect == "sybase" {
+		sb.WriteString("set quoted_identifier on\ngo\n\n")
+	}
+
+	// Las secuencias van primero porque los valores por defecto las usan; las
+	// que pertenecen a una columna las crea la cláusula de identidad
+	if supportsSequences(dialect) {
+		for _, seq := range schema.Sequences {
+			if seq.OwnedBy != "" {
+				continue
+			}
+			sb.WriteString(generateCreateSequence(seq, dialect))
+			sb.WriteString(statementTerminator(dialect))
+			sb.WriteString("\n")
+		}
+	}
+
+	for _, table := range schema.Tables {
+		sb.WriteString(generateCreateTable(table, dialect))
+		sb.WriteString(statementTerminator(dialect))
+		for _, statement := range generateCommentStatements(table, dialect) {
+			sb.WriteString(statement)
+			sb.WriteString(statementTerminator(dialect))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Las vistas van después de las tablas de las que dependen
+	for _, view := range schema.Views {
+		sb.WriteString(generateCreateView(view, dialect))
+		sb.WriteString(statementTerminator(dialect))
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), nil
+}
+
+func generateCreateTable(table Table, dialect string) string {
+	var lines []string
+
+	for _, col := range table.Columns {
+		lines = append(lines, "    "+generateColumnDefinition(col, dialect))
+	}
+
+	if pk := generatePrimaryKeyConstraint(table, dialect); pk != "" {
+		lines = append(lines, "    "+pk)
+	}
+
+	for _, check := range table.CheckConstraints {
+		lines = append(lines, fmt.Sprintf("    CONSTRAINT %s CHECK (%s)", quoteIdentifier(check.ConstraintName, dialect), check.Expression))
+	}
+
+	statement := fmt.Sprintf("CREATE TABLE %s (\n%s\n)",
+		quoteTableName(table.Schema, table.TableName, dialect),
+		strings.Join(lines, ",\n"))
+	if dialect == "mysql" && table.Description != "" {
+		statement += " COMMENT=" + quoteString(table.Description)
+	}
+	return statement
+}
+
+// Cláusula de identidad con la semilla y el incremento extraídos; sin
+// detalles se asume (1,1)
+func identityClause(col Column, dialect string) string {
+	identity := col.Identity
+	if identity == nil {
+		identity = &IdentityInfo{Seed: 1, Increment: 1}
+	}
+
+	switch dialect {
+	case "sqlserver":
+		return fmt.Sprintf("IDENTITY(%d,%d)", identity.Seed, identity.Increment)
+	case "sybase":
+		return "IDENTITY"
+	case "mysql":
+		return "AUTO_INCREMENT"
+	case "postgres":
+		clause := "GENERATED BY DEFAULT AS IDENTITY"
+		if identity.Generation == "ALWAYS" {
+			clause = "GENERATED ALWAYS AS IDENTITY"
+		}
+		if identity.Seed != 1 || identity.Increment != 1 {
+			clause += fmt.Sprintf(" (START WITH %d INCREMENT BY %d)", identity.Seed, identity.Increment)
+		}
+		return clause
+	default:
+		return ""
+	}
+}
+
+// Definición de columna: nombre, tipo, identidad, valor por defecto y nulabilidad
+func generateColumnDefinition(col Column, dialect string) string {
+	parts := []string{quoteIdentifier(col.ColumnName, dialect), formatColumnType(col, dialect)}
+
+	if col.IsIdentity {
+		parts = append(parts, identityClause(col, dialect))
+	}
+
+	if def := formatDefaultValue(col, dialect); def != "" {
+		// Solo SQL Server y Sybase dan nombre a las restricciones DEFAULT
+		if col.DefaultConstraint != "" && (dialect == "sqlserver" || dialect == "sybase") {
+			parts = append(parts, "CONSTRAINT "+quoteIdentifier(col.DefaultConstraint, dialect))
+		}
+		parts = append(parts, "DEFAULT "+def)
+	}
+
+	// Las columnas identity de Sybase no admiten la cláusula NULL/NOT NULL
+	if !(col.IsIdentity && dialect == "sybase") {
+		if col.IsNullable == "NO" || col.IsPrimaryKey {
+			parts = append(parts, "NOT NULL")
+		} else {
+			parts = append(parts, "NULL")
+		}
+	}
+
+	if dialect == "mysql" && col.Description != "" {
+		parts = append(parts, "COMMENT "+quoteString(col.Description))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Escribe el tipo con su longitud o precisión y escala cuando el tipo las
+// admite; los enteros y fechas informan precisión pero no se declara. Los
+// tipos de fecha y hora declaran DatetimePrecision siempre que se conoce,
+// aunque sea 0.
+func formatColumnType(col Column, dialect string) string {
+	dataType := baseColumnType(col, dialect)
+
+	if col.IsUnsigned && dialect == "mysql" {
+		return dataType + " unsigned"
+	}
+	return dataType
+}
+
+func baseColumnType(col Column, dialect string) string {
+	dataType := col.DataType
+
+	switch {
+	case hasLengthArgument(dataType, dialect):
+		length := col.MaxLength
+		// Sybase guarda la longitud en bytes; unichar usa 2 bytes por carácter
+		if dialect == "sybase" && strings.HasPrefix(dataType, "uni") {
+			length = length / 2
+		}
+		if length == -1 {
+			if dialect == "sqlserver" {
+				return dataType + "(max)"
+			}
+			return dataType
+		}
+		if length > 0 {
+			return fmt.Sprintf("%s(%d)", dataType, length)
+		}
+	case isDecimalType(dataType) && col.PrecisionUnspecified:
+		return fmt.Sprintf("%s(*,%d)", dataType, col.Scale)
+	case isDecimalType(dataType) && col.Precision > 0:
+		return fmt.Sprintf("%s(%d,%d)", dataType, col.Precision, col.Scale)
+	case hasFractionalSeconds(dataType, dialect) && col.DatetimePrecision != nil:
+		// En PostgreSQL los dígitos van antes de with/without time zone
+		if i := strings.Index(dataType, " with"); i > 0 {
+			return fmt.Sprintf("%s(%d)%s", dataType[:i], *col.DatetimePrecision, dataType[i:])
+		}
+		return fmt.Sprintf("%s(%d)", dataType, *col.DatetimePrecision)
+	}
+
+	return dataType
+}
+
+// Tipos de texto y binarios que declaran longitud
+func hasLengthArgument(dataType, dialect string) bool {
+	switch dialect {
+	case "sqlserver":
+		switch dataType {
+		case "char", "varchar", "nchar", "nvarchar", "binary", "varbinary":
+			return true
+		}
+	case "sybase":
+		switch dataType {
+		case "char", "varchar", "nchar", "nvarchar", "unichar", "univarchar", "binary", "varbinary":
+			return true
+		}
+	case "mysql":
+		switch dataType {
+		case "char", "varchar", "binary", "varbinary", "bit":
+			return true
+		}
+	case "postgres":
+		switch dataType {
+		case "character varying", "varchar", "character", "char", "bit", "bit varying":
+			return true
+		}
+	}
+	return false
+}
+
+func isDecimalType(dataType string) bool {
+	return dataType == "decimal" || dataType == "numeric" || dataType == "number"
+}
+
+// Tipos de fecha y hora que declaran los dígitos de fracción de segundo
+func hasFractionalSeconds(dataType, dialect string) bool {
+	switch dialect {
+	case "sqlserver":
+		return dataType == "datetime2" || dataType == "time" || dataType == "datetimeoffset"
+	case "mysql":
+		return dataType == "datetime" || dataType == "timestamp" || dataType == "time"
+	case "postgres":
+		switch dataType {
+		case "timestamp without time zone", "timestamp with time zone", "timestamp", "timestamptz",
+			"time without time zone", "time with time zone", "time", "timetz", "interval":
+			return true
+		}
+	}
+	return false
+}
+
+func setDatetimePrecision(col *Column, digits int) {
+	col.DatetimePrecision = &digits
+}
+
+// Devuelve el valor por defecto listo para la sentencia, o vacío si no aplica
+func formatDefaultValue(col Column, dialect string) string {
+	def := strings.TrimSpace(col.DefaultValue)
+	if def == "" {
+		return ""
+	}
+
+	switch dialect {
+	case "postgres":
+		// La secuencia de una columna serial la crea la cláusula de identidad
+		if col.IsIdentity && strings.HasPrefix(def, "nextval(") {
+			return ""
+		}
+	case "mysql":
+		// INFORMATION_SCHEMA guarda los literales de texto sin comillas
+		if isTextualType(col.DataType) && !strings.HasPrefix(def, "'") && !isSQLExpression(def) {
+			return quoteString(def)
+		}
+	}
+
+	return def
+}
+
+func isTextualType(dataType string) bool {
+	switch dataType {
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext", "enum", "set",
+		"date", "datetime", "timestamp", "time", "year":
+		return true
+	}
+	return false
+}
+
+func isSQLExpression(value string) bool {
+	upper := strings.ToUpper(value)
+	return strings.HasPrefix(upper, "CURRENT_TIMESTAMP") || strings.HasPrefix(upper, "NOW(") ||
+		strings.HasPrefix(upper, "NULL") || strings.HasPrefix(value, "(")
+}
+
+func quoteString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// Genera la restricción PRIMARY KEY usando el índice de clave primaria si
+// se extrajo (conserva nombre y orden), o las columnas marcadas como PK
+func generatePrimaryKeyConstraint(table Table, dialect string) string {
+	name, columns := primaryKeyDefinition(table)
+	if len(columns) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = quoteIdentifier(c, dialect)
+	}
+
+	// MySQL siempre llama PRIMARY a la clave primaria
+	if dialect == "mysql" || name == "" {
+		return fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(quoted, ", "))
+	}
+	return fmt.Sprintf("CONSTRAINT %s PRIMARY KEY (%s)", quoteIdentifier(name, dialect), strings.Join(quoted, ", "))
+}
+
+// Devuelve el nombre y las columnas ordenadas de la clave primaria
+func primaryKeyDefinition(table Table) (string, []string) {
+	for _, idx := range table.Indexes {
+		if idx.IsPrimaryKey {
+			columns := make([]string, len(idx.Columns))
+			for i, c := range idx.Columns {
+				columns[i] = c.ColumnName
+			}
+			name := idx.IndexName
+			if name == "PRIMARY" || strings.HasPrefix(name, "sqlite_autoindex_") {
+				name = ""
+			}
+			return name, columns
+		}
+	}
+
+	var columns []string
+	for _, col := range table.Columns {
+		if col.IsPrimaryKey {
+			columns = append(columns, col.ColumnName)
+		}
+	}
+	return "", columns
+}
+
+func quoteIdentifier(name, dialect string) string {
+	switch dialect {
+	case "sqlserver":
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	case "mysql":
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	default:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+}
+
+func quoteTableName(schemaName, tableName, dialect string) string {
+	// En MySQL el schema es la base de datos; se omite para que el script
+	// pueda ejecutarse sobre cualquier base
+	if schemaName == "" || dialect == "mysql" {
+		return quoteIdentifier(tableName, dialect)
+	}
+	return quoteIdentifier(schemaName, dialect) + "." + quoteIdentifier(tableName, dialect)
+}
+
+func statementTerminator(dialect string) string {
+	switch dialect {
+	case "sqlserver":
+		return "\nGO\n"
+	case "sybase":
+		return "\ngo\n"
+	default:
+		return ";\n"
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestFormatColumnType(t *testing.T) {
+	digits := func(value int) *int { return &value }
+
+	tests := []struct {
+		dialect string
+		col     Column
+		want    string
+	}{
+		{"sqlserver", Column{DataType: "nvarchar", MaxLength: 50}, "nvarchar(50)"},
+		{"sqlserver", Column{DataType: "varbinary", MaxLength: -1}, "varbinary(max)"},
+		{"sqlserver", Column{DataType: "decimal", Precision: 10, Scale: 2}, "decimal(10,2)"},
+		{"sqlserver", Column{DataType: "int", Precision: 10}, "int"},
+		// Los dígitos de fracción de segundo se declaran aunque sean 0
+		{"sqlserver", Column{DataType: "datetime2", DatetimePrecision: digits(0)}, "datetime2(0)"},
+		{"sqlserver", Column{DataType: "datetime2", DatetimePrecision: digits(7)}, "datetime2(7)"},
+		{"sqlserver", Column{DataType: "datetime2"}, "datetime2"},
+		{"sqlserver", Column{DataType: "datetime", DatetimePrecision: digits(3)}, "datetime"},
+		{"mysql", Column{DataType: "datetime", DatetimePrecision: digits(6)}, "datetime(6)"},
+		{"mysql", Column{DataType: "int", Precision: 10, IsUnsigned: true}, "int unsigned"},
+		{"postgres", Column{DataType: "timestamp without time zone", DatetimePrecision: digits(3)}, "timestamp(3) without time zone"},
+		{"postgres", Column{DataType: "time with time zone", DatetimePrecision: digits(0)}, "time(0) with time zone"},
+		{"postgres", Column{DataType: "interval", DatetimePrecision: digits(6)}, "interval(6)"},
+		{"postgres", Column{DataType: "date", DatetimePrecision: digits(0)}, "date"},
+		// Arreglos y tipos de usuario llegan con el nombre de format_type
+		{"postgres", Column{DataType: "integer[]"}, "integer[]"},
+		{"postgres", Column{DataType: "ventas.estado_pedido"}, "ventas.estado_pedido"},
+	}
+
+	for _, tt := range tests {
+		if got := formatColumnType(tt.col, tt.dialect); got != tt.want {
+			t.Errorf("%s %+v: tipo = %s, se esperaba %s", tt.dialect, tt.col, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Completa Description en la tabla y sus columnas. Sybase y SQLite no
+// guardan comentarios en el catálogo.
+func addDescriptions(db *sql.DB, dbType, schemaName string, table *Table) error {
+	if dbType == "sybase" || dbType == "sqlite" {
+		return nil
+	}
+
+	query := getDescriptionsQuery(dbType)
+	var rows *sql.Rows
+	var err error
+
+	switch dbType {
+	case "sqlserver":
+		rows, err = db.Query(query, sql.Named("schema", schemaName), sql.Named("table", table.TableName))
+	case "postgres":
+		rows, err = db.Query(query, schemaName, table.TableName)
+	case "mysql", "oracle":
+		rows, err = db.Query(query, schemaName, table.TableName, schemaName, table.TableName)
+	default:
+		return fmt.Errorf("tipo de base de datos no soportado: %s", dbType)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error al consultar descripciones: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var columnName, description sql.NullString
+
+		if err := rows.Scan(&columnName, &description); err != nil {
+			return fmt.Errorf("error al escanear descripción: %v", err)
+		}
+
+		text := strings.TrimSpace(description.String)
+		if columnName.String == "" {
+			table.Description = text
+			continue
+		}
+		for i := range table.Columns {
+			if table.Columns[i].ColumnName == columnName.String {
+				table.Columns[i].Description = text
+			}
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterando sobre descripciones: %v", err)
+	}
+
+	return nil
+}
+
+// Todas las consultas devuelven el nombre de la columna (vacío para la
+// descripción de la tabla) y la descripción; solo incluyen las que tienen texto
+func getDescriptionsQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		// minor_id 0 es la tabla; el resto son column_id
+		return `
+			SELECT
+				COALESCE(COL_NAME(ep.major_id, NULLIF(ep.minor_id, 0)), '') AS COLUMN_NAME,
+				CAST(ep.value AS nvarchar(4000)) AS DESCRIPTION
+			FROM sys.extended_properties ep
+			WHERE ep.class = 1
+			AND ep.name = 'MS_Description'
+			AND ep.major_id = OBJECT_ID(QUOTENAME(@schema) + '.' + QUOTENAME(@table))
+		`
+	case "mysql":
+		return `
+			SELECT '' AS COLUMN_NAME, TABLE_COMMENT
+			FROM INFORMATION_SCHEMA.TABLES
+			WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND TABLE_COMMENT <> ''
+			UNION ALL
+			SELECT COLUMN_NAME, COLUMN_COMMENT
+			FROM INFORMATION_SCHEMA.COLUMNS
+			WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND COLUMN_COMMENT <> ''
+		`
+	case "postgres":
+		// objsubid 0 es la tabla; el resto son attnum
+		return `
+			SELECT
+				COALESCE(a.attname, ''),
+				d.description
+			FROM pg_description d
+			LEFT JOIN pg_attribute a
+				ON a.attrelid = d.objoid
+				AND a.attnum = d.objsubid
+			WHERE d.classoid = 'pg_class'::regclass
+			AND d.objoid = format('%I.%I', $1::text, $2::text)::regclass
+			AND (d.objsubid = 0 OR NOT a.attisdropped)
+		`
+	case "oracle":
+		return `
+			SELECT NULL, comments
+			FROM all_tab_comments
+			WHERE owner = :1 AND table_name = :2 AND comments IS NOT NULL
+			UNION ALL
+			SELECT column_name, comments
+			FROM all_col_comments
+			WHERE owner = :3 AND table_name = :4 AND comments IS NOT NULL
+		`
+	default:
+		return ""
+	}
+}
+
+// Sentencias que agregan las descripciones de la tabla y sus columnas.
+// MySQL las declara dentro del CREATE TABLE y no necesita sentencias aparte.
+func generateCommentStatements(table Table, dialect string) []string {
+	var statements []string
+
+	switch dialect {
+	case "postgres":
+		tableName := quoteTableName(table.Schema, table.TableName, dialect)
+		if table.Description != "" {
+			statements = append(statements, fmt.Sprintf("COMMENT ON TABLE %s IS %s", tableName, quoteString(table.Description)))
+		}
+		for _, col := range table.Columns {
+			if col.Description != "" {
+				statements = append(statements, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s",
+					tableName, quoteIdentifier(col.ColumnName, dialect), quoteString(col.Description)))
+			}
+		}
+	case "sqlserver":
+		schemaName := table.Schema
+		if schemaName == "" {
+			schemaName = "dbo"
+		}
+		target := fmt.Sprintf("@level0type = N'SCHEMA', @level0name = %s, @level1type = N'TABLE', @level1name = %s",
+			quoteNString(schemaName), quoteNString(table.TableName))
+		if table.Description != "" {
+			statements = append(statements, fmt.Sprintf("EXEC sp_addextendedproperty @name = N'MS_Description', @value = %s, %s",
+				quoteNString(table.Description), target))
+		}
+		for _, col := range table.Columns {
+			if col.Description != "" {
+				statements = append(statements, fmt.Sprintf("EXEC sp_addextendedproperty @name = N'MS_Description', @value = %s, %s, @level2type = N'COLUMN', @level2name = %s",
+					quoteNString(col.Description), target, quoteNString(col.ColumnName)))
+			}
+		}
+	}
+
+	return statements
+}
+
+func quoteNString(value string) string {
+	return "N" + quoteString(value)
+}
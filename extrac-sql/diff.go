@@ -0,0 +1,704 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Diferencias entre dos esquemas extraídos. En MongoDB las colecciones se
+// informan como tablas y los campos inferidos como columnas.
+type SchemaDiff struct {
+	DBType string      `json:"dbType"`
+	Base   string      `json:"base"`
+	Target string      `json:"target"`
+	Tables []TableDiff `json:"tables"`
+	// Objetos del esquema, por nombre completo (solo SQL)
+	Views     []ObjectDiff `json:"views,omitempty"`
+	Routines  []ObjectDiff `json:"routines,omitempty"`
+	Sequences []ObjectDiff `json:"sequences,omitempty"`
+	// Solo con -classify o en el modo check
+	Classification *SchemaClassification `json:"classification,omitempty"`
+}
+
+// Change es added, removed, renamed o modified
+type TableDiff struct {
+	Schema      string            `json:"schema,omitempty"`
+	TableName   string            `json:"tableName"`
+	Change      string            `json:"change"`
+	RenamedFrom string            `json:"renamedFrom,omitempty"`
+	Changes     []AttributeChange `json:"changes,omitempty"`
+	Columns     []ColumnDiff      `json:"columns,omitempty"`
+	Indexes     []IndexDiff       `json:"indexes,omitempty"`
+	ForeignKeys []ObjectDiff      `json:"foreignKeys,omitempty"`
+	Checks      []ObjectDiff      `json:"checkConstraints,omitempty"`
+	Triggers    []ObjectDiff      `json:"triggers,omitempty"`
+	// Definiciones completas para quien necesite regenerar la tabla
+	OldTable *Table `json:"-"`
+	NewTable *Table `json:"-"`
+}
+
+// Change es added, removed o modified
+type ColumnDiff struct {
+	ColumnName string            `json:"columnName"`
+	Change     string            `json:"change"`
+	Changes    []AttributeChange `json:"changes,omitempty"`
+	Old        *Column           `json:"old,omitempty"`
+	New        *Column           `json:"new,omitempty"`
+}
+
+type AttributeChange struct {
+	Attribute string `json:"attribute"`
+	Old       string `json:"old"`
+	New       string `json:"new"`
+}
+
+// Old y New describen las claves y opciones del índice
+type IndexDiff struct {
+	IndexName string `json:"indexName"`
+	Change    string `json:"change"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+}
+
+// Similitud mínima entre columnas para considerar que una tabla eliminada y
+// una agregada son la misma tabla renombrada
+const renameSimilarity = 0.8
+
+func (d *SchemaDiff) HasChanges() bool {
+	return len(d.Tables) > 0 || len(d.Views) > 0 || len(d.Routines) > 0 || len(d.Sequences) > 0
+}
+
+// Indica si hay cambios dentro de la tabla, además de agregarla, eliminarla
+// o renombrarla
+func (td *TableDiff) hasDetails() bool {
+	return len(td.Changes) > 0 || len(td.Columns) > 0 || len(td.Indexes) > 0 ||
+		len(td.ForeignKeys) > 0 || len(td.Checks) > 0 || len(td.Triggers) > 0
+}
+
+// Subcomando diff: compara dos archivos JSON extraídos previamente.
+// Devuelve 0 si son iguales, 1 si hay diferencias y 2 ante un error.
+func runDiffCommand(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := flags.String("format", "text", "Formato del informe (text, json, markdown)")
+	output := flags.String("output", "", "Archivo del informe (por defecto la salida estándar)")
+	classify := flags.Bool("classify", false, "Clasificar cada cambio como additive, compatible o breaking")
+	flags.Usage = func() {
+		fmt.Println("Uso: ./extractor diff [-classify] [-format text|json|markdown] [-output archivo] base.json nuevo.json")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	diff, err := diffSchemaFiles(flags.Arg(0), flags.Arg(1))
+	if err != nil {
+		fmt.Println("Error al comparar los esquemas:", err)
+		return 2
+	}
+
+	if *classify {
+		diff.Classification = classifySchemaDiff(diff)
+	}
+
+	report, err := renderSchemaDiff(diff, strings.ToLower(*format))
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 2
+	}
+
+	if *output == "" {
+		fmt.Print(report)
+	} else {
+		err = os.WriteFile(*output, []byte(report), 0644)
+		if err != nil {
+			fmt.Printf("Error al escribir el informe: %v\n", err)
+			return 2
+		}
+		fmt.Printf("✅ Informe de diferencias guardado en: %s\n", *output)
+	}
+
+	if diff.HasChanges() {
+		return 1
+	}
+	return 0
+}
+
+// Carga ambos archivos, detectando por dbType si son esquemas SQL o MongoDB
+func diffSchemaFiles(baseFile, targetFile string) (*SchemaDiff, error) {
+	baseSQL, baseMongo, err := loadSchemaSnapshot(baseFile)
+	if err != nil {
+		return nil, err
+	}
+	targetSQL, targetMongo, err := loadSchemaSnapshot(targetFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff *SchemaDiff
+	switch {
+	case baseSQL != nil && targetSQL != nil:
+		diff = diffDatabaseSchemas(baseSQL, targetSQL)
+	case baseMongo != nil && targetMongo != nil:
+		diff = diffMongoSchemas(baseMongo, targetMongo)
+	default:
+		return nil, fmt.Errorf("no se puede comparar un esquema SQL con uno de MongoDB")
+	}
+
+	diff.Base = baseFile
+	diff.Target = targetFile
+	return diff, nil
+}
+
+func loadSchemaSnapshot(filename string) (*DatabaseSchema, *MongoSchema, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al leer archivo: %v", err)
+	}
+
+	var header struct {
+		DBType string `json:"dbType"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, nil, fmt.Errorf("error al decodificar JSON de %s: %v", filename, err)
+	}
+
+	if header.DBType == "mongodb" {
+		var schema MongoSchema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return nil, nil, fmt.Errorf("error al decodificar JSON de %s: %v", filename, err)
+		}
+		return nil, &schema, nil
+	}
+
+	var schema DatabaseSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, nil, fmt.Errorf("error al decodificar JSON de %s: %v", filename, err)
+	}
+	return &schema, nil, nil
+}
+
+// Compara dos esquemas SQL. Las tablas se identifican por schema y nombre;
+// una tabla eliminada y otra agregada con columnas casi iguales se informan
+// como un renombrado.
+func diffDatabaseSchemas(base, target *DatabaseSchema) *SchemaDiff {
+	diff := &SchemaDiff{DBType: target.DBType, Tables: []TableDiff{}}
+
+	baseTables := make(map[string]*Table)
+	for i := range base.Tables {
+		t := &base.Tables[i]
+		baseTables[qualifiedTableName(t.Schema, t.TableName)] = t
+	}
+
+	var added, removed []*Table
+	matched := make(map[string]bool)
+
+	for i := range target.Tables {
+		newTable := &target.Tables[i]
+		key := qualifiedTableName(newTable.Schema, newTable.TableName)
+		oldTable, ok := baseTables[key]
+		if !ok {
+			added = append(added, newTable)
+			continue
+		}
+		matched[key] = true
+
+		if td := diffTables(oldTable, newTable); td != nil {
+			diff.Tables = append(diff.Tables, *td)
+		}
+	}
+
+	for i := range base.Tables {
+		t := &base.Tables[i]
+		if !matched[qualifiedTableName(t.Schema, t.TableName)] {
+			removed = append(removed, t)
+		}
+	}
+
+	// Emparejar renombrados por similitud de columnas, de mayor a menor
+	renamed := make(map[*Table]*Table)
+	for _, pair := range matchRenamedTables(removed, added, tableColumnSignature) {
+		renamed[pair[1]] = pair[0]
+	}
+
+	for _, newTable := range added {
+		if oldTable, ok := renamed[newTable]; ok {
+			td := diffTables(oldTable, newTable)
+			if td == nil {
+				td = &TableDiff{Schema: newTable.Schema, TableName: newTable.TableName, OldTable: oldTable, NewTable: newTable}
+			}
+			td.Change = "renamed"
+			td.RenamedFrom = qualifiedTableName(oldTable.Schema, oldTable.TableName)
+			diff.Tables = append(diff.Tables, *td)
+			continue
+		}
+		diff.Tables = append(diff.Tables, TableDiff{
+			Schema:    newTable.Schema,
+			TableName: newTable.TableName,
+			Change:    "added",
+			NewTable:  newTable,
+		})
+	}
+
+	isRenamed := make(map[*Table]bool)
+	for _, oldTable := range renamed {
+		isRenamed[oldTable] = true
+	}
+	for _, oldTable := range removed {
+		if isRenamed[oldTable] {
+			continue
+		}
+		diff.Tables = append(diff.Tables, TableDiff{
+			Schema:    oldTable.Schema,
+			TableName: oldTable.TableName,
+			Change:    "removed",
+			OldTable:  oldTable,
+		})
+	}
+
+	sortTableDiffs(diff.Tables)
+
+	diff.Views = diffComparableObjects(viewObjects(base.Views), viewObjects(target.Views))
+	oldRoutines, newRoutines := routineObjects(base.Routines, target.Routines, target.DBType)
+	diff.Routines = diffComparableObjects(oldRoutines, newRoutines)
+	diff.Sequences = diffComparableObjects(sequenceObjects(base.Sequences), sequenceObjects(target.Sequences))
+
+	return diff
+}
+
+// Devuelve nil si las tablas son equivalentes
+func diffTables(oldTable, newTable *Table) *TableDiff {
+	td := &TableDiff{
+		Schema:    newTable.Schema,
+		TableName: newTable.TableName,
+		Change:    "modified",
+		OldTable:  oldTable,
+		NewTable:  newTable,
+	}
+
+	oldColumns := make(map[string]*Column)
+	for i := range oldTable.Columns {
+		oldColumns[oldTable.Columns[i].ColumnName] = &oldTable.Columns[i]
+	}
+	newColumns := make(map[string]bool)
+
+	for i := range newTable.Columns {
+		newCol := &newTable.Columns[i]
+		newColumns[newCol.ColumnName] = true
+
+		oldCol, ok := oldColumns[newCol.ColumnName]
+		if !ok {
+			td.Columns = append(td.Columns, ColumnDiff{ColumnName: newCol.ColumnName, Change: "added", New: newCol})
+			continue
+		}
+
+		if changes := diffColumns(oldCol, newCol); len(changes) > 0 {
+			td.Columns = append(td.Columns, ColumnDiff{
+				ColumnName: newCol.ColumnName,
+				Change:     "modified",
+				Changes:    changes,
+				Old:        oldCol,
+				New:        newCol,
+			})
+		}
+	}
+
+	for i := range oldTable.Columns {
+		oldCol := &oldTable.Columns[i]
+		if !newColumns[oldCol.ColumnName] {
+			td.Columns = append(td.Columns, ColumnDiff{ColumnName: oldCol.ColumnName, Change: "removed", Old: oldCol})
+		}
+	}
+
+	_, oldPK := primaryKeyDefinition(*oldTable)
+	_, newPK := primaryKeyDefinition(*newTable)
+	if strings.Join(oldPK, ", ") != strings.Join(newPK, ", ") {
+		td.Changes = append(td.Changes, AttributeChange{
+			Attribute: "primaryKey",
+			Old:       strings.Join(oldPK, ", "),
+			New:       strings.Join(newPK, ", "),
+		})
+	}
+
+	td.Indexes = diffSQLIndexes(oldTable.Indexes, newTable.Indexes)
+	td.ForeignKeys = diffComparableObjects(foreignKeyObjects(oldTable.ForeignKeys), foreignKeyObjects(newTable.ForeignKeys))
+	td.Checks = diffComparableObjects(checkConstraintObjects(oldTable.CheckConstraints), checkConstraintObjects(newTable.CheckConstraints))
+	td.Triggers = diffComparableObjects(triggerObjects(oldTable.Triggers), triggerObjects(newTable.Triggers))
+
+	if !td.hasDetails() {
+		return nil
+	}
+	return td
+}
+
+func diffColumns(oldCol, newCol *Column) []AttributeChange {
+	var changes []AttributeChange
+
+	compare := func(attribute, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, AttributeChange{Attribute: attribute, Old: oldValue, New: newValue})
+		}
+	}
+
+	compare("dataType", oldCol.DataType, newCol.DataType)
+	compare("maxLength", strconv.Itoa(oldCol.MaxLength), strconv.Itoa(newCol.MaxLength))
+	compare("precision", strconv.Itoa(oldCol.Precision), strconv.Itoa(newCol.Precision))
+	compare("scale", strconv.Itoa(oldCol.Scale), strconv.Itoa(newCol.Scale))
+	compare("datetimePrecision", formatOptionalDigits(oldCol.DatetimePrecision), formatOptionalDigits(newCol.DatetimePrecision))
+	compare("precisionUnspecified", strconv.FormatBool(oldCol.PrecisionUnspecified), strconv.FormatBool(newCol.PrecisionUnspecified))
+	compare("isUnsigned", strconv.FormatBool(oldCol.IsUnsigned), strconv.FormatBool(newCol.IsUnsigned))
+	compare("lengthSemantics", oldCol.LengthSemantics, newCol.LengthSemantics)
+	compare("isNullable", oldCol.IsNullable, newCol.IsNullable)
+	compare("defaultValue", strings.TrimSpace(oldCol.DefaultValue), strings.TrimSpace(newCol.DefaultValue))
+	compare("isIdentity", strconv.FormatBool(oldCol.IsIdentity), strconv.FormatBool(newCol.IsIdentity))
+	compare("isPrimaryKey", strconv.FormatBool(oldCol.IsPrimaryKey), strconv.FormatBool(newCol.IsPrimaryKey))
+
+	return changes
+}
+
+func tableColumnSignature(t *Table) []string {
+	signature := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		signature[i] = col.ColumnName + ":" + col.DataType
+	}
+	return signature
+}
+
+// Empareja elementos eliminados y agregados cuya firma (columnas o campos)
+// coincide al menos en renameSimilarity. Cada elemento se usa una sola vez.
+func matchRenamedTables(removed, added []*Table, signature func(*Table) []string) [][2]*Table {
+	type candidate struct {
+		old, new *Table
+		score    float64
+	}
+
+	var candidates []candidate
+	for _, oldTable := range removed {
+		for _, newTable := range added {
+			score := jaccardSimilarity(signature(oldTable), signature(newTable))
+			if score >= renameSimilarity {
+				candidates = append(candidates, candidate{oldTable, newTable, score})
+			}
+		}
+	}
+
+	// Orden estable para que el resultado no dependa del orden de los mapas
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	used := make(map[*Table]bool)
+	var pairs [][2]*Table
+	for _, c := range candidates {
+		if used[c.old] || used[c.new] {
+			continue
+		}
+		used[c.old], used[c.new] = true, true
+		pairs = append(pairs, [2]*Table{c.old, c.new})
+	}
+	return pairs
+}
+
+func jaccardSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool)
+	for _, s := range a {
+		set[s] = true
+	}
+
+	intersection := 0
+	union := len(set)
+	seen := make(map[string]bool)
+	for _, s := range b {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		if set[s] {
+			intersection++
+		} else {
+			union++
+		}
+	}
+
+	return float64(intersection) / float64(union)
+}
+
+func qualifiedTableName(schemaName, tableName string) string {
+	if schemaName == "" {
+		return tableName
+	}
+	return schemaName + "." + tableName
+}
+
+func sortTableDiffs(tables []TableDiff) {
+	sort.SliceStable(tables, func(i, j int) bool {
+		return qualifiedTableName(tables[i].Schema, tables[i].TableName) <
+			qualifiedTableName(tables[j].Schema, tables[j].TableName)
+	})
+}
+
+// Compara dos esquemas de MongoDB. Los campos solo se comparan cuando ambos
+// archivos incluyen el esquema inferido por muestreo.
+func diffMongoSchemas(base, target *MongoSchema) *SchemaDiff {
+	diff := &SchemaDiff{DBType: target.DBType, Tables: []TableDiff{}}
+
+	// Se reutiliza el emparejamiento de tablas representando cada colección
+	// como una tabla cuyas columnas son sus campos
+	baseCollections := make(map[string]*MongoCollection)
+	baseTables := make(map[*Table]*MongoCollection)
+	var removed []*Table
+	for i := range base.Collections {
+		baseCollections[base.Collections[i].CollectionName] = &base.Collections[i]
+	}
+
+	targetNames := make(map[string]bool)
+	addedTables := make(map[*Table]*MongoCollection)
+	var added []*Table
+
+	for i := range target.Collections {
+		newColl := &target.Collections[i]
+		targetNames[newColl.CollectionName] = true
+
+		oldColl, ok := baseCollections[newColl.CollectionName]
+		if !ok {
+			t := mongoCollectionAsTable(newColl)
+			addedTables[t] = newColl
+			added = append(added, t)
+			continue
+		}
+
+		if td := diffMongoCollections(oldColl, newColl); td != nil {
+			diff.Tables = append(diff.Tables, *td)
+		}
+	}
+
+	for i := range base.Collections {
+		oldColl := &base.Collections[i]
+		if !targetNames[oldColl.CollectionName] {
+			t := mongoCollectionAsTable(oldColl)
+			baseTables[t] = oldColl
+			removed = append(removed, t)
+		}
+	}
+
+	renamed := make(map[*Table]*Table)
+	for _, pair := range matchRenamedTables(removed, added, tableColumnSignature) {
+		renamed[pair[1]] = pair[0]
+	}
+
+	isRenamed := make(map[*Table]bool)
+	for _, t := range added {
+		newColl := addedTables[t]
+		if oldTable, ok := renamed[t]; ok {
+			isRenamed[oldTable] = true
+			td := diffMongoCollections(baseTables[oldTable], newColl)
+			if td == nil {
+				td = &TableDiff{TableName: newColl.CollectionName}
+			}
+			td.Change = "renamed"
+			td.RenamedFrom = baseTables[oldTable].CollectionName
+			diff.Tables = append(diff.Tables, *td)
+			continue
+		}
+		diff.Tables = append(diff.Tables, TableDiff{TableName: newColl.CollectionName, Change: "added"})
+	}
+
+	for _, t := range removed {
+		if !isRenamed[t] {
+			diff.Tables = append(diff.Tables, TableDiff{TableName: baseTables[t].CollectionName, Change: "removed"})
+		}
+	}
+
+	sortTableDiffs(diff.Tables)
+	return diff
+}
+
+// Representa los campos inferidos como columnas con el tipo BSON observado
+func mongoCollectionAsTable(coll *MongoCollection) *Table {
+	t := &Table{TableName: coll.CollectionName}
+	if coll.InferredSchema == nil {
+		return t
+	}
+
+	fields := flattenInferredFields(coll.InferredSchema.Fields)
+	for _, path := range sortedKeys(fields) {
+		t.Columns = append(t.Columns, Column{ColumnName: path, DataType: fields[path]})
+	}
+	return t
+}
+
+func diffMongoCollections(oldColl, newColl *MongoCollection) *TableDiff {
+	td := &TableDiff{TableName: newColl.CollectionName, Change: "modified"}
+
+	compare := func(attribute, oldValue, newValue string) {
+		if oldValue != newValue {
+			td.Changes = append(td.Changes, AttributeChange{Attribute: attribute, Old: oldValue, New: newValue})
+		}
+	}
+
+	compare("collectionType", oldColl.CollectionType, newColl.CollectionType)
+	compare("viewOn", oldColl.ViewOn, newColl.ViewOn)
+	compare("pipeline", compactJSON(oldColl.Pipeline), compactJSON(newColl.Pipeline))
+	compare("capped", strconv.FormatBool(oldColl.Capped), strconv.FormatBool(newColl.Capped))
+	compare("validator", compactJSON(oldColl.Validator), compactJSON(newColl.Validator))
+	compare("validationLevel", oldColl.ValidationLevel, newColl.ValidationLevel)
+	compare("validationAction", oldColl.ValidationAction, newColl.ValidationAction)
+	compare("expireAfterSeconds", formatOptionalInt(oldColl.ExpireAfterSeconds), formatOptionalInt(newColl.ExpireAfterSeconds))
+
+	// Campos: solo si ambos lados tienen esquema inferido
+	if oldColl.InferredSchema != nil && newColl.InferredSchema != nil {
+		oldFields := flattenInferredFields(oldColl.InferredSchema.Fields)
+		newFields := flattenInferredFields(newColl.InferredSchema.Fields)
+
+		for _, path := range sortedKeys(newFields) {
+			oldTypes, ok := oldFields[path]
+			switch {
+			case !ok:
+				td.Columns = append(td.Columns, ColumnDiff{ColumnName: path, Change: "added",
+					Changes: []AttributeChange{{Attribute: "types", New: newFields[path]}}})
+			case oldTypes != newFields[path]:
+				td.Columns = append(td.Columns, ColumnDiff{ColumnName: path, Change: "modified",
+					Changes: []AttributeChange{{Attribute: "types", Old: oldTypes, New: newFields[path]}}})
+			}
+		}
+		for _, path := range sortedKeys(oldFields) {
+			if _, ok := newFields[path]; !ok {
+				td.Columns = append(td.Columns, ColumnDiff{ColumnName: path, Change: "removed",
+					Changes: []AttributeChange{{Attribute: "types", Old: oldFields[path]}}})
+			}
+		}
+	}
+
+	// Índices por nombre
+	oldIndexes := make(map[string]string)
+	for _, idx := range oldColl.Indexes {
+		oldIndexes[idx.Name] = describeMongoIndex(idx)
+	}
+	newIndexes := make(map[string]bool)
+	for _, idx := range newColl.Indexes {
+		newIndexes[idx.Name] = true
+		desc := describeMongoIndex(idx)
+		oldDesc, ok := oldIndexes[idx.Name]
+		switch {
+		case !ok:
+			td.Indexes = append(td.Indexes, IndexDiff{IndexName: idx.Name, Change: "added", New: desc})
+		case oldDesc != desc:
+			td.Indexes = append(td.Indexes, IndexDiff{IndexName: idx.Name, Change: "modified", Old: oldDesc, New: desc})
+		}
+	}
+	for _, idx := range oldColl.Indexes {
+		if !newIndexes[idx.Name] {
+			td.Indexes = append(td.Indexes, IndexDiff{IndexName: idx.Name, Change: "removed", Old: oldIndexes[idx.Name]})
+		}
+	}
+
+	if !td.hasDetails() {
+		return nil
+	}
+	return td
+}
+
+// Devuelve ruta -> tipos BSON observados (sin null, ordenados y separados
+// por |). Los elementos de arreglos se indican como array<tipo>.
+func flattenInferredFields(fields []InferredField) map[string]string {
+	result := make(map[string]string)
+
+	var walk func([]InferredField)
+	walk = func(fields []InferredField) {
+		for _, f := range fields {
+			var types []string
+			for _, t := range f.Types {
+				if t.BSONType == "null" {
+					continue
+				}
+				if t.BSONType == "array" && len(f.ArrayElementTypes) > 0 {
+					var elements []string
+					for _, e := range f.ArrayElementTypes {
+						elements = append(elements, e.BSONType)
+					}
+					sort.Strings(elements)
+					types = append(types, "array<"+strings.Join(elements, "|")+">")
+					continue
+				}
+				types = append(types, t.BSONType)
+			}
+			sort.Strings(types)
+			result[f.Path] = strings.Join(types, "|")
+			walk(f.Fields)
+		}
+	}
+	walk(fields)
+
+	return result
+}
+
+func describeMongoIndex(idx MongoIndex) string {
+	var keys []string
+	for _, k := range idx.Keys {
+		if k.Type != "" {
+			keys = append(keys, k.Field+":"+k.Type)
+		} else {
+			keys = append(keys, fmt.Sprintf("%s:%d", k.Field, k.Direction))
+		}
+	}
+
+	desc := "{" + strings.Join(keys, ", ") + "}"
+	if idx.Unique {
+		desc += " unique"
+	}
+	if idx.Sparse {
+		desc += " sparse"
+	}
+	if idx.Hidden {
+		desc += " hidden"
+	}
+	if idx.ExpireAfterSeconds != nil {
+		desc += fmt.Sprintf(" ttl=%d", *idx.ExpireAfterSeconds)
+	}
+	if idx.PartialFilterExpression != nil {
+		desc += " partial=" + compactJSON(idx.PartialFilterExpression)
+	}
+	return desc
+}
+
+func compactJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" {
+		return ""
+	}
+	return string(data)
+}
+
+func formatOptionalInt(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(*value, 10)
+}
+
+func formatOptionalDigits(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
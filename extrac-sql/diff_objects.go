@@ -0,0 +1,358 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Clave foránea, restricción CHECK o trigger de una tabla, o vista, rutina o
+// secuencia del esquema. Old y New describen el objeto; Changes lista los
+// atributos que cambiaron cuando el objeto se modificó.
+type ObjectDiff struct {
+	Name    string            `json:"name"`
+	Change  string            `json:"change"`
+	Old     string            `json:"old,omitempty"`
+	New     string            `json:"new,omitempty"`
+	Changes []AttributeChange `json:"changes,omitempty"`
+}
+
+// Objeto preparado para compararse por nombre: la descripción se muestra al
+// agregarlo o eliminarlo y los atributos se comparan uno a uno
+type comparableObject struct {
+	name        string
+	description string
+	attributes  []objectAttribute
+}
+
+type objectAttribute struct {
+	name  string
+	value string
+}
+
+// Compara dos listas de objetos por nombre. Los agregados y modificados
+// siguen el orden de la lista nueva y los eliminados van al final.
+func diffComparableObjects(oldObjects, newObjects []comparableObject) []ObjectDiff {
+	var diffs []ObjectDiff
+
+	oldByName := make(map[string]*comparableObject)
+	for i := range oldObjects {
+		oldByName[oldObjects[i].name] = &oldObjects[i]
+	}
+	newNames := make(map[string]bool)
+
+	for _, newObject := range newObjects {
+		newNames[newObject.name] = true
+
+		oldObject, ok := oldByName[newObject.name]
+		if !ok {
+			diffs = append(diffs, ObjectDiff{Name: newObject.name, Change: "added", New: newObject.description})
+			continue
+		}
+
+		if changes := diffObjectAttributes(oldObject.attributes, newObject.attributes); len(changes) > 0 {
+			diffs = append(diffs, ObjectDiff{
+				Name:    newObject.name,
+				Change:  "modified",
+				Old:     oldObject.description,
+				New:     newObject.description,
+				Changes: changes,
+			})
+		}
+	}
+
+	for _, oldObject := range oldObjects {
+		if !newNames[oldObject.name] {
+			diffs = append(diffs, ObjectDiff{Name: oldObject.name, Change: "removed", Old: oldObject.description})
+		}
+	}
+
+	return diffs
+}
+
+func diffObjectAttributes(oldAttributes, newAttributes []objectAttribute) []AttributeChange {
+	var changes []AttributeChange
+
+	oldValues := make(map[string]string)
+	for _, a := range oldAttributes {
+		oldValues[a.name] = a.value
+	}
+	for _, a := range newAttributes {
+		if oldValues[a.name] != a.value {
+			changes = append(changes, AttributeChange{Attribute: a.name, Old: oldValues[a.name], New: a.value})
+		}
+	}
+
+	return changes
+}
+
+// Los motores devuelven las definiciones con saltos de línea y sangrías que
+// no cambian el significado
+func normalizeDefinition(definition string) string {
+	return strings.Join(strings.Fields(definition), " ")
+}
+
+func foreignKeyObjects(foreignKeys []ForeignKey) []comparableObject {
+	objects := make([]comparableObject, 0, len(foreignKeys))
+	for _, fk := range foreignKeys {
+		columns := strings.Join(fk.Columns, ", ")
+		references := qualifiedTableName(fk.ReferencedSchema, fk.ReferencedTable) + "(" + strings.Join(fk.ReferencedColumns, ", ") + ")"
+
+		desc := "(" + columns + ") → " + references
+		if fk.OnDelete != "" {
+			desc += " ON DELETE " + fk.OnDelete
+		}
+		if fk.OnUpdate != "" {
+			desc += " ON UPDATE " + fk.OnUpdate
+		}
+
+		objects = append(objects, comparableObject{
+			name:        fk.ConstraintName,
+			description: desc,
+			attributes: []objectAttribute{
+				{"columns", columns},
+				{"references", references},
+				{"onDelete", fk.OnDelete},
+				{"onUpdate", fk.OnUpdate},
+			},
+		})
+	}
+	return objects
+}
+
+func checkConstraintObjects(checks []CheckConstraint) []comparableObject {
+	objects := make([]comparableObject, 0, len(checks))
+	for _, check := range checks {
+		expression := normalizeDefinition(check.Expression)
+		objects = append(objects, comparableObject{
+			name:        check.ConstraintName,
+			description: expression,
+			attributes:  []objectAttribute{{"expression", expression}},
+		})
+	}
+	return objects
+}
+
+func triggerObjects(triggers []Trigger) []comparableObject {
+	objects := make([]comparableObject, 0, len(triggers))
+	for _, trigger := range triggers {
+		events := strings.Join(trigger.Events, ", ")
+
+		desc := strings.TrimSpace(trigger.Timing + " " + events)
+		if !trigger.Enabled {
+			desc += " (deshabilitado)"
+		}
+
+		objects = append(objects, comparableObject{
+			name:        trigger.TriggerName,
+			description: desc,
+			attributes: []objectAttribute{
+				{"timing", trigger.Timing},
+				{"events", events},
+				{"enabled", strconv.FormatBool(trigger.Enabled)},
+				{"definition", normalizeDefinition(trigger.Definition)},
+			},
+		})
+	}
+	return objects
+}
+
+func viewObjects(views []View) []comparableObject {
+	objects := make([]comparableObject, 0, len(views))
+	for _, view := range views {
+		var columns []string
+		for _, col := range view.Columns {
+			columns = append(columns, col.ColumnName)
+		}
+
+		desc := "vista"
+		if view.Materialized {
+			desc = "vista materializada"
+		}
+		desc += " (" + strings.Join(columns, ", ") + ")"
+
+		objects = append(objects, comparableObject{
+			name:        qualifiedTableName(view.Schema, view.ViewName),
+			description: desc,
+			attributes: []objectAttribute{
+				{"materialized", strconv.FormatBool(view.Materialized)},
+				{"columns", strings.Join(columns, ", ")},
+				{"definition", normalizeDefinition(view.Definition)},
+			},
+		})
+	}
+	return objects
+}
+
+// Las rutinas sobrecargadas (PostgreSQL) se distinguen por los tipos de sus
+// parámetros; el resto se identifica solo por nombre para informar los
+// cambios de parámetros como una modificación
+func routineObjects(oldRoutines, newRoutines []Routine, dbType string) ([]comparableObject, []comparableObject) {
+	counts := make(map[string]int)
+	for _, routines := range [][]Routine{oldRoutines, newRoutines} {
+		perSide := make(map[string]int)
+		for _, r := range routines {
+			key := qualifiedTableName(r.Schema, r.RoutineName)
+			perSide[key]++
+			if perSide[key] > counts[key] {
+				counts[key] = perSide[key]
+			}
+		}
+	}
+
+	convert := func(routines []Routine) []comparableObject {
+		objects := make([]comparableObject, 0, len(routines))
+		for _, r := range routines {
+			var params, types []string
+			for _, p := range r.Parameters {
+				paramType := describeParameterType(p, dbType)
+				types = append(types, paramType)
+				params = append(params, strings.TrimSpace(p.Direction+" "+p.Name+" "+paramType))
+			}
+
+			name := qualifiedTableName(r.Schema, r.RoutineName)
+			if counts[name] > 1 {
+				name += "(" + strings.Join(types, ", ") + ")"
+			}
+
+			desc := strings.ToLower(r.Kind) + "(" + strings.Join(params, ", ") + ")"
+			if r.ReturnType != "" {
+				desc += " → " + r.ReturnType
+			}
+
+			objects = append(objects, comparableObject{
+				name:        name,
+				description: desc,
+				attributes: []objectAttribute{
+					{"kind", r.Kind},
+					{"parameters", strings.Join(params, ", ")},
+					{"returnType", r.ReturnType},
+					{"language", r.Language},
+					{"definition", normalizeDefinition(r.Definition)},
+				},
+			})
+		}
+		return objects
+	}
+
+	return convert(oldRoutines), convert(newRoutines)
+}
+
+// El valor actual no se compara: cambia con cada uso de la secuencia
+func sequenceObjects(sequences []Sequence) []comparableObject {
+	objects := make([]comparableObject, 0, len(sequences))
+	for _, seq := range sequences {
+		minValue, maxValue := formatOptionalInt(seq.MinValue), formatOptionalInt(seq.MaxValue)
+
+		desc := strings.TrimSpace(fmt.Sprintf("%s START %d INCREMENT %d", seq.DataType, seq.StartValue, seq.Increment))
+		if minValue != "" {
+			desc += " MINVALUE " + minValue
+		}
+		if maxValue != "" {
+			desc += " MAXVALUE " + maxValue
+		}
+		if seq.Cycle {
+			desc += " CYCLE"
+		}
+		if seq.OwnedBy != "" {
+			desc += " OWNED BY " + seq.OwnedBy
+		}
+
+		objects = append(objects, comparableObject{
+			name:        qualifiedTableName(seq.Schema, seq.SequenceName),
+			description: desc,
+			attributes: []objectAttribute{
+				{"dataType", seq.DataType},
+				{"startValue", strconv.FormatInt(seq.StartValue, 10)},
+				{"increment", strconv.FormatInt(seq.Increment, 10)},
+				{"minValue", minValue},
+				{"maxValue", maxValue},
+				{"cycle", strconv.FormatBool(seq.Cycle)},
+				{"ownedBy", seq.OwnedBy},
+			},
+		})
+	}
+	return objects
+}
+
+// Índices SQL por nombre. Las claves primarias se informan como atributo de
+// la tabla y su nombre suele generarlo el motor, así que no se comparan aquí.
+func diffSQLIndexes(oldIndexes, newIndexes []Index) []IndexDiff {
+	var diffs []IndexDiff
+
+	oldByName := make(map[string]string)
+	for _, idx := range oldIndexes {
+		if !idx.IsPrimaryKey {
+			oldByName[idx.IndexName] = describeSQLIndex(idx)
+		}
+	}
+	newNames := make(map[string]bool)
+
+	for _, idx := range newIndexes {
+		if idx.IsPrimaryKey {
+			continue
+		}
+		newNames[idx.IndexName] = true
+
+		desc := describeSQLIndex(idx)
+		oldDesc, ok := oldByName[idx.IndexName]
+		switch {
+		case !ok:
+			diffs = append(diffs, IndexDiff{IndexName: idx.IndexName, Change: "added", New: desc})
+		case oldDesc != desc:
+			diffs = append(diffs, IndexDiff{IndexName: idx.IndexName, Change: "modified", Old: oldDesc, New: desc})
+		}
+	}
+
+	for _, idx := range oldIndexes {
+		if !idx.IsPrimaryKey && !newNames[idx.IndexName] {
+			diffs = append(diffs, IndexDiff{IndexName: idx.IndexName, Change: "removed", Old: oldByName[idx.IndexName]})
+		}
+	}
+
+	return diffs
+}
+
+func describeSQLIndex(idx Index) string {
+	var columns []string
+	for _, col := range idx.Columns {
+		columns = append(columns, strings.TrimSpace(col.ColumnName+" "+col.Order))
+	}
+
+	desc := "(" + strings.Join(columns, ", ") + ")"
+	if idx.IsUnique {
+		desc += " unique"
+	}
+	if idx.IndexType != "" {
+		desc += " " + strings.ToLower(idx.IndexType)
+	}
+	if len(idx.IncludedColumns) > 0 {
+		desc += " include(" + strings.Join(idx.IncludedColumns, ", ") + ")"
+	}
+	if idx.FilterPredicate != "" {
+		desc += " where " + normalizeDefinition(idx.FilterPredicate)
+	}
+	return desc
+}
+
+// Para objetos agregados o eliminados se muestra la descripción; para los
+// modificados, los atributos que cambiaron. Las definiciones completas solo
+// se incluyen en el informe JSON.
+func describeObjectDiff(od ObjectDiff) string {
+	switch od.Change {
+	case "added":
+		return od.New
+	case "removed":
+		return od.Old
+	}
+
+	var parts []string
+	for _, c := range od.Changes {
+		if c.Attribute == "definition" {
+			parts = append(parts, "definition modificada")
+			continue
+		}
+		parts = append(parts, c.Attribute+" "+formatAttributeChange(c))
+	}
+	return strings.Join(parts, ", ")
+}
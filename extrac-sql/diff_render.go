@@ -0,0 +1,302 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Genera el informe de diferencias en el formato indicado
+func renderSchemaDiff(diff *SchemaDiff, format string) (string, error) {
+	switch format {
+	case "text":
+		return renderDiffText(diff), nil
+	case "markdown", "md":
+		return renderDiffMarkdown(diff), nil
+	case "json":
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("error al codificar JSON: %v", err)
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("formato de informe no válido: %s (válidos: text, json, markdown)", format)
+	}
+}
+
+func changeLabel(change string) string {
+	switch change {
+	case "added":
+		return "agregada"
+	case "removed":
+		return "eliminada"
+	case "renamed":
+		return "renombrada"
+	default:
+		return "modificada"
+	}
+}
+
+func changeSymbol(change string) string {
+	switch change {
+	case "added":
+		return "+"
+	case "removed":
+		return "-"
+	case "renamed":
+		return ">"
+	default:
+		return "~"
+	}
+}
+
+func renderDiffText(diff *SchemaDiff) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Comparación de esquemas (%s)\n", diff.DBType)
+	fmt.Fprintf(&sb, "  Base:  %s\n", diff.Base)
+	fmt.Fprintf(&sb, "  Nuevo: %s\n\n", diff.Target)
+
+	if !diff.HasChanges() {
+		sb.WriteString("✅ Sin diferencias\n")
+		return sb.String()
+	}
+
+	for _, td := range diff.Tables {
+		name := qualifiedTableName(td.Schema, td.TableName)
+		if td.Change == "renamed" {
+			fmt.Fprintf(&sb, "%s Tabla renombrada: %s → %s\n", changeSymbol(td.Change), td.RenamedFrom, name)
+		} else {
+			fmt.Fprintf(&sb, "%s Tabla %s: %s\n", changeSymbol(td.Change), changeLabel(td.Change), name)
+		}
+
+		for _, c := range td.Changes {
+			fmt.Fprintf(&sb, "    %s: %s\n", c.Attribute, formatAttributeChange(c))
+		}
+		for _, cd := range td.Columns {
+			fmt.Fprintf(&sb, "    %s columna %s: %s\n", changeSymbol(cd.Change), cd.ColumnName, describeColumnDiff(cd, diff.DBType))
+		}
+		for _, id := range td.Indexes {
+			fmt.Fprintf(&sb, "    %s índice %s: %s\n", changeSymbol(id.Change), id.IndexName, describeIndexDiff(id))
+		}
+		for _, od := range td.ForeignKeys {
+			fmt.Fprintf(&sb, "    %s clave foránea %s: %s\n", changeSymbol(od.Change), od.Name, describeObjectDiff(od))
+		}
+		for _, od := range td.Checks {
+			fmt.Fprintf(&sb, "    %s check %s: %s\n", changeSymbol(od.Change), od.Name, describeObjectDiff(od))
+		}
+		for _, od := range td.Triggers {
+			fmt.Fprintf(&sb, "    %s trigger %s: %s\n", changeSymbol(od.Change), od.Name, describeObjectDiff(od))
+		}
+	}
+
+	for _, group := range schemaObjectGroups(diff) {
+		for _, od := range group.objects {
+			fmt.Fprintf(&sb, "%s %s %s: %s\n", changeSymbol(od.Change), group.kind, changeLabel(od.Change), od.Name)
+			fmt.Fprintf(&sb, "    %s\n", describeObjectDiff(od))
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(diffSummary(diff))
+	sb.WriteString("\n")
+
+	if diff.Classification != nil {
+		sb.WriteString("\n")
+		sb.WriteString(describeClassification(diff.Classification))
+	}
+
+	return sb.String()
+}
+
+func renderDiffMarkdown(diff *SchemaDiff) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Diferencias de esquema\n\n")
+	fmt.Fprintf(&sb, "- Motor: `%s`\n", diff.DBType)
+	fmt.Fprintf(&sb, "- Base: `%s`\n", diff.Base)
+	fmt.Fprintf(&sb, "- Nuevo: `%s`\n\n", diff.Target)
+
+	if !diff.HasChanges() {
+		sb.WriteString("Sin diferencias.\n")
+		return sb.String()
+	}
+
+	sb.WriteString(diffSummary(diff))
+	sb.WriteString("\n\n")
+	if len(diff.Tables) > 0 {
+		sb.WriteString("| Tabla | Cambio |\n|---|---|\n")
+		for _, td := range diff.Tables {
+			label := changeLabel(td.Change)
+			if td.Change == "renamed" {
+				label += " desde `" + td.RenamedFrom + "`"
+			}
+			fmt.Fprintf(&sb, "| `%s` | %s |\n", qualifiedTableName(td.Schema, td.TableName), label)
+		}
+		sb.WriteString("\n")
+	}
+
+	if diff.Classification != nil {
+		fmt.Fprintf(&sb, "## Clasificación: %s\n\n", diff.Classification.Level)
+		sb.WriteString("| Objeto | Nivel | Motivo |\n|---|---|---|\n")
+		for _, change := range diff.Classification.Changes {
+			fmt.Fprintf(&sb, "| `%s` | %s | %s |\n", change.Target(), change.Level, escapeMarkdownCell(change.Reason))
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, td := range diff.Tables {
+		if !td.hasDetails() {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "## `%s` (%s)\n\n", qualifiedTableName(td.Schema, td.TableName), changeLabel(td.Change))
+
+		for _, c := range td.Changes {
+			fmt.Fprintf(&sb, "- **%s**: %s\n", c.Attribute, formatAttributeChangeMarkdown(c))
+		}
+		if len(td.Changes) > 0 {
+			sb.WriteString("\n")
+		}
+
+		if len(td.Columns) > 0 {
+			sb.WriteString("| Columna | Cambio | Detalle |\n|---|---|---|\n")
+			for _, cd := range td.Columns {
+				fmt.Fprintf(&sb, "| `%s` | %s | %s |\n", cd.ColumnName, changeLabel(cd.Change),
+					escapeMarkdownCell(describeColumnDiff(cd, diff.DBType)))
+			}
+			sb.WriteString("\n")
+		}
+
+		if len(td.Indexes) > 0 {
+			sb.WriteString("| Índice | Cambio | Detalle |\n|---|---|---|\n")
+			for _, id := range td.Indexes {
+				fmt.Fprintf(&sb, "| `%s` | %s | %s |\n", id.IndexName, changeLabel(id.Change),
+					escapeMarkdownCell(describeIndexDiff(id)))
+			}
+			sb.WriteString("\n")
+		}
+
+		writeObjectDiffsMarkdown(&sb, "Clave foránea", td.ForeignKeys)
+		writeObjectDiffsMarkdown(&sb, "Check", td.Checks)
+		writeObjectDiffsMarkdown(&sb, "Trigger", td.Triggers)
+	}
+
+	for _, group := range schemaObjectGroups(diff) {
+		fmt.Fprintf(&sb, "## %s\n\n", group.title)
+		writeObjectDiffsMarkdown(&sb, group.kind, group.objects)
+	}
+
+	return sb.String()
+}
+
+func writeObjectDiffsMarkdown(sb *strings.Builder, kind string, diffs []ObjectDiff) {
+	if len(diffs) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "| %s | Cambio | Detalle |\n|---|---|---|\n", kind)
+	for _, od := range diffs {
+		fmt.Fprintf(sb, "| `%s` | %s | %s |\n", od.Name, changeLabel(od.Change), escapeMarkdownCell(describeObjectDiff(od)))
+	}
+	sb.WriteString("\n")
+}
+
+type objectDiffGroup struct {
+	kind    string
+	title   string
+	objects []ObjectDiff
+}
+
+// Objetos del esquema con cambios, en el orden de los informes
+func schemaObjectGroups(diff *SchemaDiff) []objectDiffGroup {
+	var groups []objectDiffGroup
+	for _, group := range []objectDiffGroup{
+		{"Vista", "Vistas", diff.Views},
+		{"Rutina", "Rutinas", diff.Routines},
+		{"Secuencia", "Secuencias", diff.Sequences},
+	} {
+		if len(group.objects) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+func diffSummary(diff *SchemaDiff) string {
+	counts := map[string]int{}
+	for _, td := range diff.Tables {
+		counts[td.Change]++
+	}
+	summary := fmt.Sprintf("Resumen: %d agregadas, %d eliminadas, %d renombradas, %d modificadas",
+		counts["added"], counts["removed"], counts["renamed"], counts["modified"])
+
+	if len(diff.Views) > 0 || len(diff.Routines) > 0 || len(diff.Sequences) > 0 {
+		summary += fmt.Sprintf("; %d vistas, %d rutinas y %d secuencias con cambios",
+			len(diff.Views), len(diff.Routines), len(diff.Sequences))
+	}
+	return summary
+}
+
+// Para columnas agregadas o eliminadas se muestra la definición; para las
+// modificadas, la lista de atributos que cambiaron
+func describeColumnDiff(cd ColumnDiff, dbType string) string {
+	switch {
+	case cd.Change == "added" && cd.New != nil:
+		return describeColumnDefinition(*cd.New, dbType)
+	case cd.Change == "removed" && cd.Old != nil:
+		return describeColumnDefinition(*cd.Old, dbType)
+	}
+
+	var parts []string
+	for _, c := range cd.Changes {
+		parts = append(parts, c.Attribute+" "+formatAttributeChange(c))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describeColumnDefinition(col Column, dbType string) string {
+	desc := describeColumnType(col, dbType)
+	if col.IsNullable == "NO" {
+		desc += " NOT NULL"
+	} else {
+		desc += " NULL"
+	}
+	if col.IsIdentity {
+		desc += " identity"
+	}
+	if col.DefaultValue != "" {
+		desc += " DEFAULT " + col.DefaultValue
+	}
+	return desc
+}
+
+func describeIndexDiff(id IndexDiff) string {
+	switch id.Change {
+	case "added":
+		return id.New
+	case "removed":
+		return id.Old
+	default:
+		return id.Old + " → " + id.New
+	}
+}
+
+func formatAttributeChange(c AttributeChange) string {
+	return displayValue(c.Old) + " → " + displayValue(c.New)
+}
+
+func formatAttributeChangeMarkdown(c AttributeChange) string {
+	return "`" + displayValue(c.Old) + "` → `" + displayValue(c.New) + "`"
+}
+
+func displayValue(value string) string {
+	if value == "" {
+		return "(vacío)"
+	}
+	return value
+}
+
+func escapeMarkdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
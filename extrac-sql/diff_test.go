@@ -0,0 +1,285 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testTable(name string, columns ...Column) Table {
+	return Table{TableName: name, Schema: "dbo", Columns: columns}
+}
+
+func testColumn(name, dataType string) Column {
+	return Column{ColumnName: name, DataType: dataType, IsNullable: "YES"}
+}
+
+// Resume el diff como tabla:cambio para comparar los casos
+func diffTableChanges(diff *SchemaDiff) map[string]string {
+	changes := make(map[string]string)
+	for _, td := range diff.Tables {
+		changes[qualifiedTableName(td.Schema, td.TableName)] = td.Change
+	}
+	return changes
+}
+
+func TestDiffDatabaseSchemas(t *testing.T) {
+	clientes := testTable("clientes", testColumn("id", "int"), testColumn("nombre", "varchar"), testColumn("email", "varchar"))
+
+	withIndex := clientes
+	withIndex.Indexes = []Index{{IndexName: "ix_email", Columns: []IndexColumn{{ColumnName: "email", Order: "ASC"}}}}
+
+	withFK := testTable("pedidos", testColumn("id", "int"), testColumn("cliente_id", "int"))
+	withFK.ForeignKeys = []ForeignKey{{ConstraintName: "fk_cliente", Columns: []string{"cliente_id"},
+		ReferencedSchema: "dbo", ReferencedTable: "clientes", ReferencedColumns: []string{"id"}, OnDelete: "CASCADE"}}
+	withoutCascade := withFK
+	withoutCascade.ForeignKeys = []ForeignKey{withFK.ForeignKeys[0]}
+	withoutCascade.ForeignKeys[0].OnDelete = "NO ACTION"
+
+	withTrigger := clientes
+	withTrigger.Triggers = []Trigger{{TriggerName: "tr_auditoria", Timing: "AFTER", Events: []string{"UPDATE"}, Enabled: true, Definition: "BEGIN END"}}
+	withCheck := clientes
+	withCheck.CheckConstraints = []CheckConstraint{{ConstraintName: "ck_clientes_nombre", Expression: "len(nombre) > 0"}}
+	disabledTrigger := clientes
+	disabledTrigger.Triggers = []Trigger{withTrigger.Triggers[0]}
+	disabledTrigger.Triggers[0].Enabled = false
+
+	tests := []struct {
+		name        string
+		base        []Table
+		target      []Table
+		wantTables  map[string]string
+		wantChanges bool
+	}{
+		{
+			name:       "sin cambios",
+			base:       []Table{clientes},
+			target:     []Table{clientes},
+			wantTables: map[string]string{},
+		},
+		{
+			name:        "tabla agregada y eliminada",
+			base:        []Table{testTable("viejos", testColumn("a", "int"))},
+			target:      []Table{testTable("nuevos", testColumn("b", "date"))},
+			wantTables:  map[string]string{"dbo.viejos": "removed", "dbo.nuevos": "added"},
+			wantChanges: true,
+		},
+		{
+			name:        "columna agregada",
+			base:        []Table{clientes},
+			target:      []Table{testTable("clientes", append(clientes.Columns[:3:3], testColumn("alta", "date"))...)},
+			wantTables:  map[string]string{"dbo.clientes": "modified"},
+			wantChanges: true,
+		},
+		{
+			name:        "tabla renombrada",
+			base:        []Table{clientes},
+			target:      []Table{testTable("customers", clientes.Columns...)},
+			wantTables:  map[string]string{"dbo.customers": "renamed"},
+			wantChanges: true,
+		},
+		{
+			name:        "índice eliminado",
+			base:        []Table{withIndex},
+			target:      []Table{clientes},
+			wantTables:  map[string]string{"dbo.clientes": "modified"},
+			wantChanges: true,
+		},
+		{
+			name:        "acción de la clave foránea modificada",
+			base:        []Table{withFK},
+			target:      []Table{withoutCascade},
+			wantTables:  map[string]string{"dbo.pedidos": "modified"},
+			wantChanges: true,
+		},
+		{
+			name:        "check nuevo",
+			base:        []Table{clientes},
+			target:      []Table{withCheck},
+			wantTables:  map[string]string{"dbo.clientes": "modified"},
+			wantChanges: true,
+		},
+		{
+			name:        "trigger deshabilitado",
+			base:        []Table{withTrigger},
+			target:      []Table{disabledTrigger},
+			wantTables:  map[string]string{"dbo.clientes": "modified"},
+			wantChanges: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := diffDatabaseSchemas(
+				&DatabaseSchema{DBType: "sqlserver", Tables: tt.base},
+				&DatabaseSchema{DBType: "sqlserver", Tables: tt.target},
+			)
+
+			got := diffTableChanges(diff)
+			if len(got) != len(tt.wantTables) {
+				t.Fatalf("tablas = %v, se esperaba %v", got, tt.wantTables)
+			}
+			for name, change := range tt.wantTables {
+				if got[name] != change {
+					t.Errorf("%s: cambio = %q, se esperaba %q", name, got[name], change)
+				}
+			}
+			if diff.HasChanges() != tt.wantChanges {
+				t.Errorf("HasChanges() = %v, se esperaba %v", diff.HasChanges(), tt.wantChanges)
+			}
+		})
+	}
+}
+
+func TestMatchRenamedTables(t *testing.T) {
+	clientes := testTable("clientes", testColumn("id", "int"), testColumn("nombre", "varchar"), testColumn("email", "varchar"),
+		testColumn("alta", "date"), testColumn("activo", "bit"))
+	pedidos := testTable("pedidos", testColumn("id", "int"), testColumn("total", "decimal"))
+
+	customers := testTable("customers", clientes.Columns...)
+	// 4 de 5 columnas iguales: similitud 4/6, por debajo del umbral
+	partial := testTable("parcial", append(clientes.Columns[:4:4], testColumn("pais", "varchar"))...)
+	orders := testTable("orders", pedidos.Columns...)
+
+	tests := []struct {
+		name    string
+		removed []*Table
+		added   []*Table
+		want    map[string]string
+	}{
+		{
+			name:    "sin candidatos",
+			removed: nil,
+			added:   []*Table{&customers},
+			want:    map[string]string{},
+		},
+		{
+			name:    "renombrados por columnas iguales",
+			removed: []*Table{&clientes, &pedidos},
+			added:   []*Table{&orders, &customers},
+			want:    map[string]string{"clientes": "customers", "pedidos": "orders"},
+		},
+		{
+			name:    "similitud insuficiente",
+			removed: []*Table{&clientes},
+			added:   []*Table{&partial},
+			want:    map[string]string{},
+		},
+		{
+			name:    "cada tabla se usa una sola vez",
+			removed: []*Table{&clientes},
+			added:   []*Table{&partial, &customers},
+			want:    map[string]string{"clientes": "customers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs := matchRenamedTables(tt.removed, tt.added, tableColumnSignature)
+
+			got := make(map[string]string)
+			for _, pair := range pairs {
+				got[pair[0].TableName] = pair[1].TableName
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("pares = %v, se esperaba %v", got, tt.want)
+			}
+			for oldName, newName := range tt.want {
+				if got[oldName] != newName {
+					t.Errorf("%s renombrada a %q, se esperaba %q", oldName, got[oldName], newName)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffDatabaseSchemasViews(t *testing.T) {
+	base := &DatabaseSchema{DBType: "postgres", Tables: []Table{}, Views: []View{
+		{ViewName: "v_activos", Schema: "public", Columns: []Column{{ColumnName: "id"}, {ColumnName: "nombre"}}, Definition: "SELECT id, nombre\n  FROM clientes"},
+		{ViewName: "v_saldos", Schema: "public", Columns: []Column{{ColumnName: "id"}}, Definition: "SELECT id FROM cuentas"},
+		{ViewName: "v_viejos", Schema: "public", Definition: "SELECT 1"},
+	}}
+	target := &DatabaseSchema{DBType: "postgres", Tables: []Table{}, Views: []View{
+		// Solo cambian los espacios de la definición
+		{ViewName: "v_activos", Schema: "public", Columns: []Column{{ColumnName: "id"}, {ColumnName: "nombre"}}, Definition: "SELECT id, nombre FROM clientes"},
+		{ViewName: "v_saldos", Schema: "public", Columns: []Column{{ColumnName: "id"}}, Definition: "SELECT id FROM cuentas WHERE activa"},
+		{ViewName: "mv_ventas", Schema: "public", Materialized: true, Definition: "SELECT 1"},
+	}}
+
+	diff := diffDatabaseSchemas(base, target)
+
+	got := make(map[string]string)
+	for _, od := range diff.Views {
+		got[od.Name] = od.Change
+	}
+	want := map[string]string{"public.v_saldos": "modified", "public.mv_ventas": "added", "public.v_viejos": "removed"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("vistas = %v, se esperaba %v", got, want)
+	}
+	if !diff.HasChanges() {
+		t.Errorf("HasChanges() = false con vistas modificadas")
+	}
+	if desc := describeObjectDiff(diff.Views[0]); desc != "definition modificada" {
+		t.Errorf("descripción de public.v_saldos = %q, se esperaba solo la definición modificada", desc)
+	}
+}
+
+func TestDiffDatabaseSchemasRoutines(t *testing.T) {
+	param := func(name, dataType string) RoutineParameter {
+		return RoutineParameter{Name: name, Direction: "IN", DataType: dataType}
+	}
+	sumarInt := Routine{RoutineName: "sumar", Schema: "public", Kind: "FUNCTION", ReturnType: "integer",
+		Parameters: []RoutineParameter{param("a", "integer"), param("b", "integer")}}
+	sumarNumeric := Routine{RoutineName: "sumar", Schema: "public", Kind: "FUNCTION", ReturnType: "numeric",
+		Parameters: []RoutineParameter{param("a", "numeric"), param("b", "numeric")}}
+	cerrar := Routine{RoutineName: "cerrar_mes", Schema: "public", Kind: "PROCEDURE", Parameters: []RoutineParameter{param("mes", "integer")}}
+	cerrarConAnio := cerrar
+	cerrarConAnio.Parameters = append(cerrar.Parameters[:1:1], param("anio", "integer"))
+
+	diff := diffDatabaseSchemas(
+		&DatabaseSchema{DBType: "postgres", Tables: []Table{}, Routines: []Routine{sumarInt, sumarNumeric, cerrar}},
+		&DatabaseSchema{DBType: "postgres", Tables: []Table{}, Routines: []Routine{sumarInt, cerrarConAnio}},
+	)
+
+	got := make(map[string]string)
+	for _, od := range diff.Routines {
+		got[od.Name] = od.Change
+	}
+	// Las sobrecargas se distinguen por los tipos de sus parámetros; el resto
+	// se compara por nombre
+	want := map[string]string{"public.cerrar_mes": "modified", "public.sumar(numeric, numeric)": "removed"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rutinas = %v, se esperaba %v", got, want)
+	}
+	if len(diff.Routines) > 0 && (len(diff.Routines[0].Changes) != 1 || diff.Routines[0].Changes[0].Attribute != "parameters") {
+		t.Errorf("cambios de public.cerrar_mes = %+v, se esperaba solo parameters", diff.Routines[0].Changes)
+	}
+}
+
+func TestDiffDatabaseSchemasSequences(t *testing.T) {
+	current := func(value int64) *int64 { return &value }
+	seqPedidos := Sequence{SequenceName: "seq_pedidos", Schema: "public", DataType: "bigint", StartValue: 1, Increment: 1, CurrentValue: current(10)}
+	usada := seqPedidos
+	usada.CurrentValue = current(250)
+	porDiez := seqPedidos
+	porDiez.Increment = 10
+
+	// El valor actual cambia con cada uso y no cuenta como diferencia
+	diff := diffDatabaseSchemas(
+		&DatabaseSchema{DBType: "postgres", Tables: []Table{}, Sequences: []Sequence{seqPedidos}},
+		&DatabaseSchema{DBType: "postgres", Tables: []Table{}, Sequences: []Sequence{usada}},
+	)
+	if diff.HasChanges() {
+		t.Errorf("secuencias = %+v, no se esperaban cambios", diff.Sequences)
+	}
+
+	diff = diffDatabaseSchemas(
+		&DatabaseSchema{DBType: "postgres", Tables: []Table{}, Sequences: []Sequence{seqPedidos}},
+		&DatabaseSchema{DBType: "postgres", Tables: []Table{}, Sequences: []Sequence{porDiez}},
+	)
+	want := []ObjectDiff{{Name: "public.seq_pedidos", Change: "modified",
+		Old: "bigint START 1 INCREMENT 1", New: "bigint START 1 INCREMENT 10",
+		Changes: []AttributeChange{{Attribute: "increment", Old: "1", New: "10"}}}}
+	if !reflect.DeepEqual(diff.Sequences, want) {
+		t.Errorf("secuencias = %+v, se esperaba %+v", diff.Sequences, want)
+	}
+}
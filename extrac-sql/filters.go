@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Filtro de nombres con patrones de inclusión y exclusión. Los patrones glob
+// (audit_*) no distinguen mayúsculas y deben coincidir con el nombre
+// completo; los escritos entre barras (/^tmp_\d+$/) son expresiones regulares.
+type NameFilter struct {
+	Include []*regexp.Regexp
+	Exclude []*regexp.Regexp
+}
+
+// Compila las listas de patrones separados por coma de -include/-exclude
+func newNameFilter(include, exclude string) (NameFilter, error) {
+	var filter NameFilter
+	var err error
+
+	filter.Include, err = compilePatternList(include)
+	if err != nil {
+		return filter, err
+	}
+	filter.Exclude, err = compilePatternList(exclude)
+	if err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// Indica si alguno de los nombres (p. ej. tabla y schema.tabla) pasa el
+// filtro: debe coincidir con algún patrón de inclusión, si los hay, y con
+// ninguno de exclusión
+func (f NameFilter) Allows(names ...string) bool {
+	if len(f.Include) > 0 && !matchesAnyRegexp(f.Include, names) {
+		return false
+	}
+	return !matchesAnyRegexp(f.Exclude, names)
+}
+
+// Indica si el filtro no tiene patrones
+func (f NameFilter) IsEmpty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Descarta las columnas que no pasan el filtro; los patrones se comparan con
+// columna, tabla.columna y schema.tabla.columna
+func filterColumns(columns []Column, filter NameFilter, schemaName, tableName string) []Column {
+	if filter.IsEmpty() {
+		return columns
+	}
+
+	var filtered []Column
+	for _, col := range columns {
+		if filter.Allows(col.ColumnName, tableName+"."+col.ColumnName,
+			qualifiedTableName(schemaName, tableName)+"."+col.ColumnName) {
+			filtered = append(filtered, col)
+		}
+	}
+	return filtered
+}
+
+func matchesAnyRegexp(patterns []*regexp.Regexp, names []string) bool {
+	for _, pattern := range patterns {
+		for _, name := range names {
+			if pattern.MatchString(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func compilePatternList(value string) ([]*regexp.Regexp, error) {
+	return compilePatterns(splitPatternList(value))
+}
+
+// Compila cada patrón (glob o /expresión regular/) por separado
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+
+		var expr string
+		if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			expr = pattern[1 : len(pattern)-1]
+		} else {
+			expr = globToRegexp(pattern)
+		}
+
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("patrón no válido %s: %v", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	return compiled, nil
+}
+
+// Separa los patrones por coma, salvo las comas dentro de una expresión
+// regular entre barras como /^log_\d{1,3}$/
+func splitPatternList(value string) []string {
+	var patterns []string
+	var current strings.Builder
+	inRegexp := false
+
+	flush := func() {
+		if pattern := strings.TrimSpace(current.String()); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+		current.Reset()
+	}
+
+	for _, r := range value {
+		switch {
+		case r == '/' && strings.TrimSpace(current.String()) == "":
+			inRegexp = true
+		case r == '/' && inRegexp:
+			inRegexp = false
+		case r == ',' && !inRegexp:
+			flush()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	flush()
+
+	return patterns
+}
+
+// Traduce un patrón glob (*, ? y [...]) a una expresión regular anclada
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("(?i)^")
+
+	inClass := false
+	for _, r := range glob {
+		switch {
+		case inClass:
+			if r == ']' {
+				inClass = false
+			}
+			if r == '\\' {
+				sb.WriteString(`\\`)
+			} else {
+				sb.WriteRune(r)
+			}
+		case r == '*':
+			sb.WriteString(".*")
+		case r == '?':
+			sb.WriteString(".")
+		case r == '[':
+			inClass = true
+			sb.WriteRune(r)
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	sb.WriteString("$")
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestSplitPatternList(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{"clientes", []string{"clientes"}},
+		{"clientes, pedidos ,audit_*", []string{"clientes", "pedidos", "audit_*"}},
+		{",,clientes,", []string{"clientes"}},
+		{`/^log_\d{1,3}$/,tmp_*`, []string{`/^log_\d{1,3}$/`, "tmp_*"}},
+		{`tmp_*, /^(a|b),c$/`, []string{"tmp_*", `/^(a|b),c$/`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := splitPatternList(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitPatternList(%q) = %q, se esperaba %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob    string
+		want    string
+		match   []string
+		noMatch []string
+	}{
+		{"clientes", "(?i)^clientes$", []string{"clientes", "CLIENTES"}, []string{"clientes_old", "mis_clientes"}},
+		{"audit_*", "(?i)^audit_.*$", []string{"audit_", "audit_2024"}, []string{"audit", "x_audit_1"}},
+		{"log_?", "(?i)^log_.$", []string{"log_1"}, []string{"log_", "log_12"}},
+		{"dbo.t[0-9]", `(?i)^dbo\.t[0-9]$`, []string{"dbo.t1"}, []string{"dboxt1", "dbo.ta"}},
+		{"a+b", `(?i)^a\+b$`, []string{"a+b"}, []string{"aab"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob, func(t *testing.T) {
+			got := globToRegexp(tt.glob)
+			if got != tt.want {
+				t.Errorf("globToRegexp(%q) = %q, se esperaba %q", tt.glob, got, tt.want)
+			}
+
+			re := regexp.MustCompile(got)
+			for _, name := range tt.match {
+				if !re.MatchString(name) {
+					t.Errorf("%q debería coincidir con %q", name, tt.glob)
+				}
+			}
+			for _, name := range tt.noMatch {
+				if re.MatchString(name) {
+					t.Errorf("%q no debería coincidir con %q", name, tt.glob)
+				}
+			}
+		})
+	}
+}
+
+func TestNameFilterAllows(t *testing.T) {
+	filter, err := newNameFilter("ventas.*,/^log_\\d+$/", "ventas.tmp_*")
+	if err != nil {
+		t.Fatalf("newNameFilter: %v", err)
+	}
+
+	tests := []struct {
+		names []string
+		want  bool
+	}{
+		{[]string{"pedidos", "ventas.pedidos"}, true},
+		{[]string{"tmp_carga", "ventas.tmp_carga"}, false},
+		{[]string{"log_12", "dbo.log_12"}, true},
+		{[]string{"log_x", "dbo.log_x"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := filter.Allows(tt.names...); got != tt.want {
+			t.Errorf("Allows(%q) = %v, se esperaba %v", tt.names, got, tt.want)
+		}
+	}
+}
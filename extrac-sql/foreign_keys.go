@@ -0,0 +1,236 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Estructura para almacenar la información de una clave foránea.
+// Las columnas locales y referenciadas se guardan en el mismo orden,
+// de modo que Columns[i] referencia a ReferencedColumns[i].
+type ForeignKey struct {
+	ConstraintName    string   `json:"constraintName"`
+	Columns           []string `json:"columns"`
+	ReferencedSchema  string   `json:"referencedSchema"`
+	ReferencedTable   string   `json:"referencedTable"`
+	ReferencedColumns []string `json:"referencedColumns"`
+	OnDelete          string   `json:"onDelete,omitempty"`
+	OnUpdate          string   `json:"onUpdate,omitempty"`
+}
+
+func extractForeignKeys(db *sql.DB, dbType, schemaName, tableName string) ([]ForeignKey, error) {
+	// Para Sybase, construimos la consulta dinámicamente sin parámetros
+	if dbType == "sybase" {
+		return extractSybaseForeignKeys(db, schemaName, tableName)
+	}
+
+	if dbType == "sqlite" {
+		return extractSQLiteForeignKeys(db, tableName)
+	}
+
+	if dbType == "oracle" {
+		return extractOracleForeignKeys(db, schemaName, tableName)
+	}
+
+	query := getForeignKeysQuery(dbType)
+	var rows *sql.Rows
+	var err error
+
+	switch dbType {
+	case "sqlserver":
+		rows, err = db.Query(query, sql.Named("schema", schemaName), sql.Named("table", tableName))
+	case "mysql", "postgres":
+		rows, err = db.Query(query, schemaName, tableName)
+	default:
+		return nil, fmt.Errorf("tipo de base de datos no soportado: %s", dbType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar claves foráneas: %v", err)
+	}
+	defer rows.Close()
+
+	return scanForeignKeys(rows)
+}
+
+// Todas las consultas devuelven una fila por columna de la clave, ordenadas
+// por restricción y posición, con las columnas:
+// constraint_name, column_name, referenced_schema, referenced_table,
+// referenced_column, on_delete, on_update
+func getForeignKeysQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		// Se usa sys.foreign_key_columns en lugar de INFORMATION_SCHEMA porque
+		// REFERENTIAL_CONSTRAINTS no resuelve las columnas cuando la clave
+		// referencia un índice único en lugar de una restricción
+		return `
+			SELECT
+				fk.name AS CONSTRAINT_NAME,
+				pc.name AS COLUMN_NAME,
+				SCHEMA_NAME(rt.schema_id) AS REFERENCED_SCHEMA,
+				rt.name AS REFERENCED_TABLE,
+				rc.name AS REFERENCED_COLUMN,
+				REPLACE(fk.delete_referential_action_desc, '_', ' ') AS ON_DELETE,
+				REPLACE(fk.update_referential_action_desc, '_', ' ') AS ON_UPDATE
+			FROM sys.foreign_keys fk
+			INNER JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+			INNER JOIN sys.tables pt ON pt.object_id = fk.parent_object_id
+			INNER JOIN sys.columns pc ON pc.object_id = fkc.parent_object_id AND pc.column_id = fkc.parent_column_id
+			INNER JOIN sys.tables rt ON rt.object_id = fk.referenced_object_id
+			INNER JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+			WHERE SCHEMA_NAME(pt.schema_id) = @schema
+				AND pt.name = @table
+			ORDER BY fk.name, fkc.constraint_column_id
+		`
+	case "mysql":
+		return `
+			SELECT
+				kcu.CONSTRAINT_NAME,
+				kcu.COLUMN_NAME,
+				kcu.REFERENCED_TABLE_SCHEMA,
+				kcu.REFERENCED_TABLE_NAME,
+				kcu.REFERENCED_COLUMN_NAME,
+				rc.DELETE_RULE,
+				rc.UPDATE_RULE
+			FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS rc
+			INNER JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu
+				ON kcu.CONSTRAINT_SCHEMA = rc.CONSTRAINT_SCHEMA
+				AND kcu.CONSTRAINT_NAME = rc.CONSTRAINT_NAME
+				AND kcu.TABLE_NAME = rc.TABLE_NAME
+			WHERE kcu.TABLE_SCHEMA = ? AND kcu.TABLE_NAME = ?
+			ORDER BY kcu.CONSTRAINT_NAME, kcu.ORDINAL_POSITION
+		`
+	case "postgres":
+		return `
+			SELECT
+				con.conname AS constraint_name,
+				att.attname AS column_name,
+				ref_nsp.nspname AS referenced_schema,
+				ref_cls.relname AS referenced_table,
+				ref_att.attname AS referenced_column,
+				CASE con.confdeltype
+					WHEN 'a' THEN 'NO ACTION'
+					WHEN 'r' THEN 'RESTRICT'
+					WHEN 'c' THEN 'CASCADE'
+					WHEN 'n' THEN 'SET NULL'
+					WHEN 'd' THEN 'SET DEFAULT'
+				END AS on_delete,
+				CASE con.confupdtype
+					WHEN 'a' THEN 'NO ACTION'
+					WHEN 'r' THEN 'RESTRICT'
+					WHEN 'c' THEN 'CASCADE'
+					WHEN 'n' THEN 'SET NULL'
+					WHEN 'd' THEN 'SET DEFAULT'
+				END AS on_update
+			FROM pg_constraint con
+			JOIN pg_class cls ON cls.oid = con.conrelid
+			JOIN pg_namespace nsp ON nsp.oid = cls.relnamespace
+			JOIN pg_class ref_cls ON ref_cls.oid = con.confrelid
+			JOIN pg_namespace ref_nsp ON ref_nsp.oid = ref_cls.relnamespace
+			CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, ref_attnum, ord)
+			JOIN pg_attribute att ON att.attrelid = con.conrelid AND att.attnum = k.attnum
+			JOIN pg_attribute ref_att ON ref_att.attrelid = con.confrelid AND ref_att.attnum = k.ref_attnum
+			WHERE con.contype = 'f'
+			  AND nsp.nspname = $1
+			  AND cls.relname = $2
+			ORDER BY con.conname, k.ord
+		`
+	default:
+		return ""
+	}
+}
+
+// Función específica para extraer claves foráneas de Sybase (sin parámetros).
+// sysreferences guarda hasta 16 columnas por clave en fokey1..fokey16 y
+// refkey1..refkey16, así que se genera una fila por posición con UNION ALL.
+func extractSybaseForeignKeys(db *sql.DB, schemaName, tableName string) ([]ForeignKey, error) {
+	var parts []string
+	for i := 1; i <= 16; i++ {
+		parts = append(parts, fmt.Sprintf(`
+		SELECT
+			object_name(r.constrid) as constraint_name,
+			%d as key_position,
+			col_name(r.tableid, r.fokey%d) as column_name,
+			user_name(o.uid) as referenced_schema,
+			o.name as referenced_table,
+			col_name(r.reftabid, r.refkey%d) as referenced_column
+		FROM sysreferences r
+		JOIN sysobjects o ON o.id = r.reftabid
+		WHERE r.tableid = object_id('%s.%s')
+		AND r.keycnt >= %d`, i, i, i, schemaName, tableName, i))
+	}
+	query := strings.Join(parts, "\n\t\tUNION ALL") + "\n\t\tORDER BY 1, 2\n"
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar claves foráneas: %v", err)
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKey
+
+	for rows.Next() {
+		var constraintName, columnName, refSchema, refTable, refColumn string
+		var position int
+
+		err := rows.Scan(&constraintName, &position, &columnName, &refSchema, &refTable, &refColumn)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear clave foránea: %v", err)
+		}
+
+		// Sybase ASE no soporta acciones en cascada: las restricciones
+		// declarativas siempre impiden borrar o actualizar la fila referenciada
+		foreignKeys = appendForeignKeyColumn(foreignKeys, constraintName, columnName,
+			refSchema, refTable, refColumn, "RESTRICT", "RESTRICT")
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre claves foráneas: %v", err)
+	}
+
+	return foreignKeys, nil
+}
+
+func scanForeignKeys(rows *sql.Rows) ([]ForeignKey, error) {
+	var foreignKeys []ForeignKey
+
+	for rows.Next() {
+		var constraintName, columnName, refSchema, refTable, refColumn string
+		var onDelete, onUpdate sql.NullString
+
+		err := rows.Scan(&constraintName, &columnName, &refSchema, &refTable, &refColumn, &onDelete, &onUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear clave foránea: %v", err)
+		}
+
+		foreignKeys = appendForeignKeyColumn(foreignKeys, constraintName, columnName,
+			refSchema, refTable, refColumn, onDelete.String, onUpdate.String)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre claves foráneas: %v", err)
+	}
+
+	return foreignKeys, nil
+}
+
+// Agrega una columna a la última clave foránea si pertenece a la misma
+// restricción (las filas llegan ordenadas), o inicia una clave nueva.
+func appendForeignKeyColumn(foreignKeys []ForeignKey, constraintName, columnName, refSchema, refTable, refColumn, onDelete, onUpdate string) []ForeignKey {
+	if n := len(foreignKeys); n > 0 && foreignKeys[n-1].ConstraintName == constraintName {
+		foreignKeys[n-1].Columns = append(foreignKeys[n-1].Columns, columnName)
+		foreignKeys[n-1].ReferencedColumns = append(foreignKeys[n-1].ReferencedColumns, refColumn)
+		return foreignKeys
+	}
+
+	return append(foreignKeys, ForeignKey{
+		ConstraintName:    constraintName,
+		Columns:           []string{columnName},
+		ReferencedSchema:  refSchema,
+		ReferencedTable:   refTable,
+		ReferencedColumns: []string{refColumn},
+		OnDelete:          onDelete,
+		OnUpdate:          onUpdate,
+	})
+}
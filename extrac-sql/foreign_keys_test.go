@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAppendForeignKeyColumn(t *testing.T) {
+	type row struct {
+		constraint, column, refTable, refColumn string
+	}
+
+	rows := []row{
+		{"fk_pedido_cliente", "cliente_id", "clientes", "id"},
+		{"fk_linea_pedido", "pedido_id", "pedidos", "id"},
+		{"fk_linea_pedido", "linea", "pedidos", "linea"},
+		{"fk_linea_producto", "producto_id", "productos", "id"},
+	}
+
+	var foreignKeys []ForeignKey
+	for _, r := range rows {
+		foreignKeys = appendForeignKeyColumn(foreignKeys, r.constraint, r.column, "dbo", r.refTable, r.refColumn, "CASCADE", "NO ACTION")
+	}
+
+	want := []ForeignKey{
+		{ConstraintName: "fk_pedido_cliente", Columns: []string{"cliente_id"}, ReferencedSchema: "dbo", ReferencedTable: "clientes",
+			ReferencedColumns: []string{"id"}, OnDelete: "CASCADE", OnUpdate: "NO ACTION"},
+		{ConstraintName: "fk_linea_pedido", Columns: []string{"pedido_id", "linea"}, ReferencedSchema: "dbo", ReferencedTable: "pedidos",
+			ReferencedColumns: []string{"id", "linea"}, OnDelete: "CASCADE", OnUpdate: "NO ACTION"},
+		{ConstraintName: "fk_linea_producto", Columns: []string{"producto_id"}, ReferencedSchema: "dbo", ReferencedTable: "productos",
+			ReferencedColumns: []string{"id"}, OnDelete: "CASCADE", OnUpdate: "NO ACTION"},
+	}
+	if !reflect.DeepEqual(foreignKeys, want) {
+		t.Errorf("claves foráneas = %+v, se esperaba %+v", foreignKeys, want)
+	}
+}
+
+func TestGetForeignKeysQuery(t *testing.T) {
+	for _, dbType := range []string{"sqlserver", "mysql", "postgres"} {
+		if getForeignKeysQuery(dbType) == "" {
+			t.Errorf("%s: consulta de claves foráneas vacía", dbType)
+		}
+	}
+
+	// Los motores sin consulta se rechazan antes de usar la conexión
+	if _, err := extractForeignKeys(nil, "db2", "dbo", "clientes"); err == nil {
+		t.Errorf("se esperaba un error para un tipo de base de datos no soportado")
+	}
+}
@@ -6,6 +6,8 @@ require (
 	github.com/denisenkom/go-mssqldb v0.12.3
 	github.com/go-sql-driver/mysql v1.7.1
 	github.com/lib/pq v1.10.9
+	github.com/mattn/go-sqlite3 v1.14.17
+	github.com/sijms/go-ora/v2 v2.7.6
 	github.com/thda/tds v0.1.6
 	go.mongodb.org/mongo-driver v1.12.1
 )
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Estructura para almacenar la información de un índice o restricción única.
+// Las claves primarias y restricciones UNIQUE también se listan aquí porque
+// cada motor las implementa con un índice.
+type Index struct {
+	IndexName          string        `json:"indexName"`
+	Columns            []IndexColumn `json:"columns"`
+	IsUnique           bool          `json:"isUnique"`
+	IsPrimaryKey       bool          `json:"isPrimaryKey"`
+	IsUniqueConstraint bool          `json:"isUniqueConstraint"`
+	IsClustered        bool          `json:"isClustered"`
+	IndexType          string        `json:"indexType,omitempty"`
+	FilterPredicate    string        `json:"filterPredicate,omitempty"`
+	IncludedColumns    []string      `json:"includedColumns,omitempty"`
+}
+
+// Columna clave de un índice con su orden (ASC o DESC)
+type IndexColumn struct {
+	ColumnName string `json:"columnName"`
+	Order      string `json:"order"`
+}
+
+// withoutRowID solo se usa en SQLite, igual que en extractTableColumns
+func extractIndexes(db *sql.DB, dbType, schemaName, tableName string, withoutRowID bool) ([]Index, error) {
+	if dbType == "sqlite" {
+		return extractSQLiteIndexes(db, tableName, withoutRowID)
+	}
+
+	if dbType == "oracle" {
+		return extractOracleIndexes(db, schemaName, tableName)
+	}
+
+	query := getIndexesQuery(dbType, schemaName, tableName)
+	var rows *sql.Rows
+	var err error
+
+	switch dbType {
+	case "sqlserver":
+		rows, err = db.Query(query, sql.Named("schema", schemaName), sql.Named("table", tableName))
+	case "mysql", "postgres":
+		rows, err = db.Query(query, schemaName, tableName)
+	case "sybase":
+		// La consulta de Sybase ya incluye el nombre de la tabla
+		rows, err = db.Query(query)
+	default:
+		return nil, fmt.Errorf("tipo de base de datos no soportado: %s", dbType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar índices: %v", err)
+	}
+	defer rows.Close()
+
+	var indexes []Index
+
+	for rows.Next() {
+		var indexName, columnName, sortOrder, indexType, filterPredicate string
+		var isIncluded, isUnique, isPrimaryKey, isUniqueConstraint, isClustered int
+
+		err := rows.Scan(
+			&indexName,
+			&columnName,
+			&sortOrder,
+			&isIncluded,
+			&isUnique,
+			&isPrimaryKey,
+			&isUniqueConstraint,
+			&isClustered,
+			&indexType,
+			&filterPredicate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear índice: %v", err)
+		}
+
+		indexes = appendIndexColumn(indexes, Index{
+			IndexName:          indexName,
+			IsUnique:           isUnique == 1,
+			IsPrimaryKey:       isPrimaryKey == 1,
+			IsUniqueConstraint: isUniqueConstraint == 1,
+			IsClustered:        isClustered == 1,
+			IndexType:          indexType,
+			FilterPredicate:    filterPredicate,
+		}, columnName, sortOrder, isIncluded == 1)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre índices: %v", err)
+	}
+
+	return indexes, nil
+}
+
+// Agrega la columna al último índice si tiene el mismo nombre (las filas
+// llegan ordenadas por índice), o agrega el índice nuevo con la columna
+func appendIndexColumn(indexes []Index, index Index, columnName, sortOrder string, included bool) []Index {
+	n := len(indexes)
+	if n == 0 || indexes[n-1].IndexName != index.IndexName {
+		index.Columns = []IndexColumn{}
+		indexes = append(indexes, index)
+		n++
+	}
+
+	if included {
+		indexes[n-1].IncludedColumns = append(indexes[n-1].IncludedColumns, columnName)
+	} else {
+		indexes[n-1].Columns = append(indexes[n-1].Columns, IndexColumn{
+			ColumnName: columnName,
+			Order:      sortOrder,
+		})
+	}
+
+	return indexes
+}
+
+// Todas las consultas devuelven una fila por columna del índice, ordenadas
+// por índice y posición, con las columnas:
+// index_name, column_name, sort_order, is_included, is_unique,
+// is_primary_key, is_unique_constraint, is_clustered, index_type,
+// filter_predicate
+func getIndexesQuery(dbType, schemaName, tableName string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				i.name AS INDEX_NAME,
+				c.name AS COLUMN_NAME,
+				CASE WHEN ic.is_descending_key = 1 THEN 'DESC' ELSE 'ASC' END AS SORT_ORDER,
+				CASE WHEN ic.is_included_column = 1 THEN 1 ELSE 0 END AS IS_INCLUDED,
+				CASE WHEN i.is_unique = 1 THEN 1 ELSE 0 END AS IS_UNIQUE,
+				CASE WHEN i.is_primary_key = 1 THEN 1 ELSE 0 END AS IS_PRIMARY_KEY,
+				CASE WHEN i.is_unique_constraint = 1 THEN 1 ELSE 0 END AS IS_UNIQUE_CONSTRAINT,
+				CASE WHEN i.type IN (1, 5) THEN 1 ELSE 0 END AS IS_CLUSTERED,
+				i.type_desc AS INDEX_TYPE,
+				COALESCE(i.filter_definition, '') AS FILTER_PREDICATE
+			FROM sys.indexes i
+			INNER JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+			INNER JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+			INNER JOIN sys.tables t ON t.object_id = i.object_id
+			WHERE SCHEMA_NAME(t.schema_id) = @schema
+				AND t.name = @table
+				AND i.type > 0  -- Excluir el heap
+			ORDER BY i.name, ic.is_included_column, ic.key_ordinal, ic.index_column_id
+		`
+	case "sybase":
+		// index_col/index_colorder devuelven la columna y el orden para cada
+		// posición de la clave; spt_values provee la secuencia de posiciones
+		objectName := schemaName + "." + tableName
+		return fmt.Sprintf(`
+			SELECT
+				i.name as index_name,
+				index_col('%s', i.indid, v.number) as column_name,
+				isnull(index_colorder('%s', i.indid, v.number), 'ASC') as sort_order,
+				0 as is_included,
+				CASE WHEN i.status & 2 = 2 THEN 1 ELSE 0 END as is_unique,
+				CASE WHEN i.status & 2048 = 2048 THEN 1 ELSE 0 END as is_primary_key,
+				CASE WHEN i.status & 4096 = 4096 THEN 1 ELSE 0 END as is_unique_constraint,
+				CASE WHEN i.indid = 1 OR i.status2 & 512 = 512 THEN 1 ELSE 0 END as is_clustered,
+				CASE WHEN i.indid = 1 OR i.status2 & 512 = 512 THEN 'CLUSTERED' ELSE 'NONCLUSTERED' END as index_type,
+				'' as filter_predicate
+			FROM sysindexes i, master..spt_values v
+			WHERE i.id = object_id('%s')
+			AND i.indid > 0 AND i.indid < 255  -- Excluir tabla sin índice y text/image
+			AND v.type = 'P'
+			AND v.number BETWEEN 1 AND i.keycnt
+			AND index_col('%s', i.indid, v.number) IS NOT NULL
+			ORDER BY i.name, v.number
+		`, objectName, objectName, objectName, objectName)
+	case "mysql":
+		// En InnoDB la clave primaria es el índice agrupado
+		return `
+			SELECT
+				s.INDEX_NAME,
+				COALESCE(s.COLUMN_NAME, '') AS COLUMN_NAME,
+				CASE WHEN s.COLLATION = 'D' THEN 'DESC' ELSE 'ASC' END AS SORT_ORDER,
+				0 AS IS_INCLUDED,
+				CASE WHEN s.NON_UNIQUE = 0 THEN 1 ELSE 0 END AS IS_UNIQUE,
+				CASE WHEN s.INDEX_NAME = 'PRIMARY' THEN 1 ELSE 0 END AS IS_PRIMARY_KEY,
+				CASE WHEN tc.CONSTRAINT_TYPE = 'UNIQUE' THEN 1 ELSE 0 END AS IS_UNIQUE_CONSTRAINT,
+				CASE WHEN s.INDEX_NAME = 'PRIMARY' AND t.ENGINE = 'InnoDB' THEN 1 ELSE 0 END AS IS_CLUSTERED,
+				s.INDEX_TYPE,
+				'' AS FILTER_PREDICATE
+			FROM INFORMATION_SCHEMA.STATISTICS s
+			INNER JOIN INFORMATION_SCHEMA.TABLES t
+				ON t.TABLE_SCHEMA = s.TABLE_SCHEMA
+				AND t.TABLE_NAME = s.TABLE_NAME
+			LEFT JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+				ON tc.TABLE_SCHEMA = s.TABLE_SCHEMA
+				AND tc.TABLE_NAME = s.TABLE_NAME
+				AND tc.CONSTRAINT_NAME = s.INDEX_NAME
+				AND tc.CONSTRAINT_TYPE = 'UNIQUE'
+			WHERE s.TABLE_SCHEMA = ? AND s.TABLE_NAME = ?
+			ORDER BY s.INDEX_NAME, s.SEQ_IN_INDEX
+		`
+	case "postgres":
+		// Las columnas posteriores a indnkeyatts son columnas incluidas
+		// (INCLUDE); las expresiones se obtienen con pg_get_indexdef
+		return `
+			SELECT
+				ic.relname AS index_name,
+				COALESCE(a.attname, pg_get_indexdef(i.indexrelid, k.ord::int, true)) AS column_name,
+				CASE WHEN COALESCE(i.indoption[(k.ord - 1)::int], 0) & 1 = 1 THEN 'DESC' ELSE 'ASC' END AS sort_order,
+				CASE WHEN k.ord > i.indnkeyatts THEN 1 ELSE 0 END AS is_included,
+				CASE WHEN i.indisunique THEN 1 ELSE 0 END AS is_unique,
+				CASE WHEN i.indisprimary THEN 1 ELSE 0 END AS is_primary_key,
+				CASE WHEN con.contype = 'u' THEN 1 ELSE 0 END AS is_unique_constraint,
+				CASE WHEN i.indisclustered THEN 1 ELSE 0 END AS is_clustered,
+				am.amname AS index_type,
+				COALESCE(pg_get_expr(i.indpred, i.indrelid), '') AS filter_predicate
+			FROM pg_index i
+			JOIN pg_class ic ON ic.oid = i.indexrelid
+			JOIN pg_class tc ON tc.oid = i.indrelid
+			JOIN pg_namespace n ON n.oid = tc.relnamespace
+			JOIN pg_am am ON am.oid = ic.relam
+			LEFT JOIN pg_constraint con ON con.conindid = i.indexrelid AND con.contype = 'u'
+			CROSS JOIN LATERAL unnest(i.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+			LEFT JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum AND k.attnum <> 0
+			WHERE n.nspname = $1
+			  AND tc.relname = $2
+			ORDER BY ic.relname, k.ord
+		`
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAppendIndexColumn(t *testing.T) {
+	pk := Index{IndexName: "pk_pedidos", IsUnique: true, IsPrimaryKey: true, IsClustered: true, IndexType: "CLUSTERED"}
+	ix := Index{IndexName: "ix_pedidos_fecha", IndexType: "NONCLUSTERED", FilterPredicate: "([anulado]=(0))"}
+
+	var indexes []Index
+	indexes = appendIndexColumn(indexes, pk, "id", "ASC", false)
+	indexes = appendIndexColumn(indexes, ix, "fecha", "DESC", false)
+	indexes = appendIndexColumn(indexes, ix, "cliente_id", "ASC", false)
+	indexes = appendIndexColumn(indexes, ix, "total", "ASC", true)
+
+	wantPK := pk
+	wantPK.Columns = []IndexColumn{{ColumnName: "id", Order: "ASC"}}
+	wantIX := ix
+	wantIX.Columns = []IndexColumn{{ColumnName: "fecha", Order: "DESC"}, {ColumnName: "cliente_id", Order: "ASC"}}
+	wantIX.IncludedColumns = []string{"total"}
+
+	if want := []Index{wantPK, wantIX}; !reflect.DeepEqual(indexes, want) {
+		t.Errorf("índices = %+v, se esperaba %+v", indexes, want)
+	}
+}
+
+func TestGetIndexesQuery(t *testing.T) {
+	for _, dbType := range []string{"sqlserver", "mysql", "postgres", "sybase"} {
+		if getIndexesQuery(dbType, "dbo", "pedidos") == "" {
+			t.Errorf("%s: consulta de índices vacía", dbType)
+		}
+	}
+
+	if _, err := extractIndexes(nil, "db2", "dbo", "pedidos", false); err == nil {
+		t.Errorf("se esperaba un error para un tipo de base de datos no soportado")
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net/url"
 	"os"
 	"strings"
 
 	_ "github.com/denisenkom/go-mssqldb"
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	_ "github.com/sijms/go-ora/v2"
 	_ "github.com/thda/tds"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -19,15 +23,43 @@ import (
 
 // Configuración de la conexión a la base de datos
 type Config struct {
-	DBType   string
-	Server   string
-	Port     int
-	User     string
-	Password string
-	Database string
-	Schema   string
-	Output   string
-	SSLMode  string // Para PostgreSQL
+	DBType     string
+	Server     string
+	Port       int
+	User       string
+	Password   string
+	Database   string
+	Schema     string
+	Output     string
+	Format     string // Formato de salida: json o ddl
+	Input      string // Esquema JSON previo en lugar de una conexión
+	Target     string // Dialecto al que convertir el esquema
+	TypeMap    string // Reglas de conversión de tipos (JSON)
+	Report     string // Archivo del informe de conversión
+	SSLMode    string // Para PostgreSQL
+	SampleSize int    // Documentos a muestrear por colección (MongoDB)
+	Validator  bool   // Generar validador $jsonSchema (MongoDB)
+	ValidatorOptions
+	// Modo de servidor: extraer todas las bases que pasan los filtros
+	AllDatabases    bool
+	DatabaseInclude string // Patrones glob separados por coma
+	DatabaseExclude string
+	Combined        bool // Un solo catálogo en lugar de un archivo por base
+	// Tablas (o colecciones) y columnas a extraer
+	TableFilter  NameFilter
+	ColumnFilter NameFilter
+	// Tablas o colecciones que se procesan a la vez sobre la misma conexión
+	Parallel int
+	// Destino de los mensajes de progreso de la extracción; nil es la salida
+	// estándar. check los envía a stderr cuando el informe va a stdout.
+	Progress io.Writer
+}
+
+func (c Config) progress() io.Writer {
+	if c.Progress == nil {
+		return os.Stdout
+	}
+	return c.Progress
 }
 
 // Estructura para almacenar la información de una columna
@@ -41,59 +73,155 @@ type Column struct {
 	IsPrimaryKey bool   `json:"isPrimaryKey"`
 	IsIdentity   bool   `json:"isIdentity"`
 	DefaultValue string `json:"defaultValue,omitempty"`
+	// Unidad de MaxLength en Oracle: BYTE o CHAR
+	LengthSemantics string `json:"lengthSemantics,omitempty"`
+	// NUMBER(*,s) de Oracle: la escala se declaró sin precisión, así que Scale
+	// vale aunque sea 0
+	PrecisionUnspecified bool `json:"precisionUnspecified,omitempty"`
+	// Dígitos de fracción de segundo de los tipos de fecha y hora; nil si el
+	// catálogo no los informa
+	DatetimePrecision *int `json:"datetimePrecision,omitempty"`
+	// Enteros UNSIGNED de MySQL
+	IsUnsigned bool `json:"isUnsigned,omitempty"`
+	// Semilla, incremento y generación de las columnas identity
+	Identity *IdentityInfo `json:"identity,omitempty"`
+	// Nombre de la restricción DEFAULT en SQL Server y Sybase
+	DefaultConstraint string `json:"defaultConstraint,omitempty"`
+	// Comentario de la columna (MS_Description en SQL Server)
+	Description string `json:"description,omitempty"`
 }
 
 // Estructura para almacenar la información de una tabla
 type Table struct {
-	TableName string   `json:"tableName"`
-	Schema    string   `json:"schema"`
-	Columns   []Column `json:"columns"`
+	TableName        string            `json:"tableName"`
+	Schema           string            `json:"schema"`
+	Columns          []Column          `json:"columns"`
+	ForeignKeys      []ForeignKey      `json:"foreignKeys,omitempty"`
+	Indexes          []Index           `json:"indexes,omitempty"`
+	Triggers         []Trigger         `json:"triggers,omitempty"`
+	CheckConstraints []CheckConstraint `json:"checkConstraints,omitempty"`
+	// Opciones de tabla propias de SQLite
+	WithoutRowID bool `json:"withoutRowId,omitempty"`
+	Strict       bool `json:"strict,omitempty"`
+	// Comentario de la tabla (MS_Description en SQL Server)
+	Description string `json:"description,omitempty"`
 }
 
 // Estructura principal que contiene todas las tablas
 type DatabaseSchema struct {
-	DatabaseName string  `json:"databaseName"`
-	DBType       string  `json:"dbType"`
-	Schema       string  `json:"defaultSchema"`
-	Tables       []Table `json:"tables"`
+	DatabaseName string     `json:"databaseName"`
+	DBType       string     `json:"dbType"`
+	Schema       string     `json:"defaultSchema"`
+	Tables       []Table    `json:"tables"`
+	Views        []View     `json:"views,omitempty"`
+	Routines     []Routine  `json:"routines,omitempty"`
+	Sequences    []Sequence `json:"sequences,omitempty"`
+	// Objetos extraídos agrupados por schema
+	Schemas []SchemaSummary `json:"schemas,omitempty"`
+	// Tablas o metadatos del catálogo que no se pudieron extraer
+	Errors []ObjectError `json:"errors,omitempty"`
 }
 
 // Estructura para MongoDB
 type MongoCollection struct {
-	CollectionName string                 `json:"collectionName"`
-	DatabaseName   string                 `json:"databaseName"`
-	Indexes        []MongoIndex           `json:"indexes,omitempty"`
-	SampleDocument map[string]interface{} `json:"sampleDocument,omitempty"`
+	CollectionName string `json:"collectionName"`
+	DatabaseName   string `json:"databaseName"`
+	// Tipo de colección: collection, view o timeseries
+	CollectionType string                   `json:"collectionType"`
+	ViewOn         string                   `json:"viewOn,omitempty"`
+	Pipeline       []map[string]interface{} `json:"pipeline,omitempty"`
+	Capped         bool                     `json:"capped,omitempty"`
+	CappedSize     int64                    `json:"cappedSize,omitempty"`
+	CappedMax      int64                    `json:"cappedMax,omitempty"`
+	// Validador existente en la colección y cómo se aplica
+	Validator          map[string]interface{}  `json:"validator,omitempty"`
+	ValidationLevel    string                  `json:"validationLevel,omitempty"`
+	ValidationAction   string                  `json:"validationAction,omitempty"`
+	Timeseries         *MongoTimeseriesOptions `json:"timeseries,omitempty"`
+	ClusteredIndex     *MongoClusteredIndex    `json:"clusteredIndex,omitempty"`
+	ExpireAfterSeconds *int64                  `json:"expireAfterSeconds,omitempty"`
+	Indexes            []MongoIndex            `json:"indexes,omitempty"`
+	SampleDocument     map[string]interface{}  `json:"sampleDocument,omitempty"`
+	InferredSchema     *InferredSchema         `json:"inferredSchema,omitempty"`
+	// Validador $jsonSchema sugerido a partir del esquema inferido
+	SuggestedValidator map[string]interface{} `json:"suggestedValidator,omitempty"`
 }
 
 type MongoIndex struct {
-	Name   string          `json:"name"`
-	Keys   []MongoIndexKey `json:"keys"`
-	Unique bool            `json:"unique"`
+	Name                    string                 `json:"name"`
+	Keys                    []MongoIndexKey        `json:"keys"`
+	Unique                  bool                   `json:"unique"`
+	Sparse                  bool                   `json:"sparse,omitempty"`
+	Hidden                  bool                   `json:"hidden,omitempty"`
+	IsWildcard              bool                   `json:"isWildcard,omitempty"`
+	ExpireAfterSeconds      *int64                 `json:"expireAfterSeconds,omitempty"`
+	PartialFilterExpression map[string]interface{} `json:"partialFilterExpression,omitempty"`
+	Collation               map[string]interface{} `json:"collation,omitempty"`
+	WildcardProjection      map[string]interface{} `json:"wildcardProjection,omitempty"`
+	Weights                 map[string]interface{} `json:"weights,omitempty"`
+	DefaultLanguage         string                 `json:"defaultLanguage,omitempty"`
 }
 
+// Direction es 1 o -1 para claves ordenadas; los índices especiales
+// (text, 2d, 2dsphere, hashed) usan Type en lugar de Direction
 type MongoIndexKey struct {
 	Field     string `json:"field"`
-	Direction int    `json:"direction"`
+	Direction int    `json:"direction,omitempty"`
+	Type      string `json:"type,omitempty"`
 }
 
 type MongoSchema struct {
 	DatabaseName string            `json:"databaseName"`
 	DBType       string            `json:"dbType"`
 	Collections  []MongoCollection `json:"collections"`
+	// Colecciones que no se pudieron extraer
+	Errors []ObjectError `json:"errors,omitempty"`
 }
 
 func main() {
+	// Subcomando diff: compara dos esquemas guardados en JSON
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Exit(runDiffCommand(os.Args[2:]))
+	}
+
+	// Subcomando migrate: genera el script ALTER entre dos esquemas
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrateCommand(os.Args[2:]))
+	}
+
+	// Subcomando check: detecta desvíos de la base en vivo contra una línea base
+	if len(os.Args) > 1 && os.Args[1] == "check" {
+		os.Exit(runCheckCommand(os.Args[2:]))
+	}
+
 	// Definir flags
-	dbType := flag.String("dbtype", "", "Tipo de base de datos (sqlserver, sybase, mysql, postgres, mongodb)")
+	dbType := flag.String("dbtype", "", "Tipo de base de datos (sqlserver, sybase, mysql, postgres, oracle, sqlite, mongodb)")
 	server := flag.String("server", "localhost", "Servidor de la base de datos")
 	port := flag.Int("port", 0, "Puerto de la base de datos (se usará el puerto por defecto según el tipo)")
 	user := flag.String("user", "", "Usuario de la base de datos")
 	password := flag.String("password", "", "Contraseña de la base de datos")
-	database := flag.String("database", "", "Nombre de la base de datos")
-	schema := flag.String("schema", "dbo", "Schema por defecto (para bases de datos que lo soportan)")
+	database := flag.String("database", "", "Nombre de la base de datos (servicio para Oracle, ruta del archivo para SQLite)")
+	schema := flag.String("schema", "dbo", "Schema, lista separada por comas, patrón glob o * para todos (para bases de datos que lo soportan)")
 	output := flag.String("output", "database_schema.json", "Archivo de salida JSON")
+	format := flag.String("format", "json", "Formato de salida (json, ddl)")
+	input := flag.String("input", "", "Archivo JSON de un esquema extraído previamente (no se conecta a la base de datos)")
+	target := flag.String("target", "", "Convertir el esquema a otro dialecto (sqlserver, sybase, mysql, postgres)")
+	typeMap := flag.String("typemap", "", "Archivo JSON con reglas de conversión de tipos (requiere -target)")
+	report := flag.String("report", "", "Archivo JSON para el informe de conversión (requiere -target)")
 	sslMode := flag.String("sslmode", "disable", "Modo SSL (para PostgreSQL)")
+	sampleSize := flag.Int("sample", 0, "Documentos a muestrear por colección para inferir el esquema (MongoDB, 0 = desactivado)")
+	validator := flag.Bool("validator", false, "Generar un validador $jsonSchema por colección (MongoDB, requiere -sample)")
+	requiredRatio := flag.Float64("requiredratio", 1.0, "Presencia mínima (0-1) para marcar un campo como requerido en el validador")
+	enumMax := flag.Int("enummax", 10, "Máximo de valores distintos para generar un enum en el validador")
+	allDatabases := flag.Bool("alldatabases", false, "Extraer todas las bases de datos del servidor")
+	dbInclude := flag.String("dbinclude", "", "Patrones de bases a incluir, separados por coma (requiere -alldatabases)")
+	dbExclude := flag.String("dbexclude", "", "Patrones de bases a excluir, separados por coma (requiere -alldatabases)")
+	combined := flag.Bool("combined", false, "Guardar todas las bases en un solo catálogo JSON (requiere -alldatabases)")
+	include := flag.String("include", "", "Tablas o colecciones a incluir: patrones glob o /regex/ separados por coma")
+	exclude := flag.String("exclude", "", "Tablas o colecciones a excluir: patrones glob o /regex/ separados por coma")
+	includeColumns := flag.String("includecolumns", "", "Columnas a incluir: patrones glob o /regex/ separados por coma")
+	excludeColumns := flag.String("excludecolumns", "", "Columnas a excluir: patrones glob o /regex/ separados por coma")
+	parallel := flag.Int("parallel", 1, "Tablas o colecciones a procesar en paralelo; también limita las conexiones abiertas")
 	help := flag.Bool("help", false, "Mostrar ayuda")
 
 	flag.Parse()
@@ -104,8 +232,80 @@ func main() {
 		return
 	}
 
-	// Validar parámetros requeridos
-	if *dbType == "" || *user == "" || *password == "" || *database == "" {
+	// Validar formato de salida
+	*format = strings.ToLower(*format)
+	if *format != "json" && *format != "ddl" {
+		fmt.Printf("Error: Formato de salida no válido: %s\n", *format)
+		fmt.Println("Formatos válidos: json, ddl")
+		os.Exit(1)
+	}
+	if *format == "ddl" && *output == "database_schema.json" {
+		*output = "database_schema.sql"
+	}
+
+	// Validar la conversión entre dialectos
+	*target = strings.ToLower(*target)
+	if *target != "" && !isDDLDialect(*target) {
+		fmt.Printf("Error: Dialecto destino no válido: %s\n", *target)
+		fmt.Printf("Dialectos válidos: %s\n", strings.Join(ddlDialects, ", "))
+		os.Exit(1)
+	}
+	if *target == "" && (*typeMap != "" || *report != "") {
+		fmt.Println("Error: Los parámetros -typemap y -report requieren -target")
+		os.Exit(1)
+	}
+
+	// Generar la salida a partir de un esquema guardado, sin conexión
+	if *input != "" {
+		processSchemaFile(Config{
+			Input:   *input,
+			Output:  *output,
+			Format:  *format,
+			Target:  *target,
+			TypeMap: *typeMap,
+			Report:  *report,
+		})
+		return
+	}
+
+	if !*allDatabases && (*dbInclude != "" || *dbExclude != "" || *combined) {
+		fmt.Println("Error: Los parámetros -dbinclude, -dbexclude y -combined requieren -alldatabases")
+		os.Exit(1)
+	}
+	if *combined && *format != "json" {
+		fmt.Println("Error: El catálogo combinado solo admite el formato de salida json")
+		os.Exit(1)
+	}
+
+	// En el modo de servidor la base indicada solo se usa para conectarse
+	if *allDatabases && *database == "" {
+		*database = serverDefaultDatabase(strings.ToLower(*dbType))
+	}
+
+	if *requiredRatio < 0 || *requiredRatio > 1 {
+		fmt.Println("Error: El parámetro -requiredratio debe estar entre 0 y 1")
+		os.Exit(1)
+	}
+
+	if *parallel < 1 {
+		fmt.Println("Error: El parámetro -parallel debe ser mayor o igual que 1")
+		os.Exit(1)
+	}
+
+	tableFilter, err := newNameFilter(*include, *exclude)
+	if err != nil {
+		fmt.Printf("Error: Filtro de tablas no válido: %v\n", err)
+		os.Exit(1)
+	}
+	columnFilter, err := newNameFilter(*includeColumns, *excludeColumns)
+	if err != nil {
+		fmt.Printf("Error: Filtro de columnas no válido: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Validar parámetros requeridos (SQLite abre un archivo local sin credenciales)
+	isSQLite := strings.ToLower(*dbType) == "sqlite"
+	if *dbType == "" || (*database == "" && !*allDatabases) || (!isSQLite && (*user == "" || *password == "")) {
 		fmt.Println("Error: Los parámetros dbtype, user, password y database son requeridos")
 		fmt.Println("\nUso:")
 		flag.PrintDefaults()
@@ -119,42 +319,169 @@ func main() {
 
 	// Configuración de la conexión
 	config := Config{
-		DBType:   strings.ToLower(*dbType),
-		Server:   *server,
-		Port:     *port,
-		User:     *user,
-		Password: *password,
-		Database: *database,
-		Schema:   *schema,
-		Output:   *output,
-		SSLMode:  *sslMode,
+		DBType:     strings.ToLower(*dbType),
+		Server:     *server,
+		Port:       *port,
+		User:       *user,
+		Password:   *password,
+		Database:   *database,
+		Schema:     *schema,
+		Output:     *output,
+		Format:     *format,
+		Target:     *target,
+		TypeMap:    *typeMap,
+		Report:     *report,
+		SSLMode:    *sslMode,
+		SampleSize: *sampleSize,
+		Validator:  *validator,
+		ValidatorOptions: ValidatorOptions{
+			RequiredRatio: *requiredRatio,
+			EnumMaxValues: *enumMax,
+		},
+		AllDatabases:    *allDatabases,
+		DatabaseInclude: *dbInclude,
+		DatabaseExclude: *dbExclude,
+		Combined:        *combined,
+		TableFilter:     tableFilter,
+		ColumnFilter:    columnFilter,
+		Parallel:        *parallel,
 	}
 
 	// Validar tipo de base de datos
 	if !isValidDBType(config.DBType) {
 		fmt.Printf("Error: Tipo de base de datos no válido: %s\n", config.DBType)
-		fmt.Println("Tipos válidos: sqlserver, sybase, mysql, postgres, mongodb")
+		fmt.Println("Tipos válidos: sqlserver, sybase, mysql, postgres, oracle, sqlite, mongodb")
+		os.Exit(1)
+	}
+
+	applySchemaDefaults(&config)
+
+	if config.DBType == "mongodb" && config.Format != "json" {
+		fmt.Println("Error: MongoDB solo admite el formato de salida json")
+		os.Exit(1)
+	}
+
+	if config.DBType == "mongodb" && config.Target != "" {
+		fmt.Println("Error: La conversión entre dialectos solo está disponible para bases SQL")
+		os.Exit(1)
+	}
+
+	// El validador se genera a partir del esquema inferido por muestreo
+	if config.Validator && config.SampleSize <= 0 {
+		fmt.Println("Error: El parámetro -validator requiere -sample mayor que 0")
+		os.Exit(1)
+	}
+
+	if config.AllDatabases && !supportsServerMode(config.DBType) {
+		fmt.Printf("Error: El modo de servidor no está disponible para %s\n", config.DBType)
 		os.Exit(1)
 	}
 
 	fmt.Printf("Configuración:\n")
 	fmt.Printf("  Tipo de BD: %s\n", config.DBType)
 	fmt.Printf("  Servidor: %s:%d\n", config.Server, config.Port)
-	fmt.Printf("  Base de datos: %s\n", config.Database)
+	if config.AllDatabases {
+		fmt.Printf("  Base de datos: todas las del servidor (conexión inicial: %s)\n", config.Database)
+	} else {
+		fmt.Printf("  Base de datos: %s\n", config.Database)
+	}
 	fmt.Printf("  Schema: %s\n", config.Schema)
 	fmt.Printf("  Archivo de salida: %s\n", config.Output)
 	fmt.Println()
 
-	// Procesar según el tipo de base de datos
-	if config.DBType == "mongodb" {
-		processMongoDB(config)
+	// Procesar según el tipo de base de datos. Los errores se informan aquí
+	// para que las conexiones se cierren antes de salir.
+	if config.AllDatabases {
+		err = processServer(config)
+	} else if config.DBType == "mongodb" {
+		err = processMongoDB(config)
 	} else {
-		processSQLDatabase(config)
+		err = processSQLDatabase(config)
+	}
+	if err != nil {
+		log.Fatal("Error: ", err)
+	}
+}
+
+// Ajusta el schema a las convenciones de cada motor
+func applySchemaDefaults(config *Config) {
+	// SQLite no tiene schemas de usuario
+	if config.DBType == "sqlite" {
+		config.Schema = sqliteSchema
+	}
+
+	// En MySQL el schema es la base de datos
+	if config.DBType == "mysql" && config.Schema == "dbo" {
+		config.Schema = config.Database
+	}
+
+	// En Oracle el schema es el owner de las tablas: por defecto el propio
+	// usuario, y en mayúsculas como lo guarda el diccionario de datos
+	if config.DBType == "oracle" {
+		if config.Schema == "dbo" {
+			config.Schema = config.User
+		}
+		config.Schema = strings.ToUpper(config.Schema)
+	}
+}
+
+// Parámetros de conexión compartidos por los subcomandos que extraen el
+// esquema de una base SQL en vivo
+type connectionFlags struct {
+	dbType, server, user, password, database, schema, sslMode *string
+	port                                                      *int
+}
+
+func addConnectionFlags(flags *flag.FlagSet) *connectionFlags {
+	return &connectionFlags{
+		dbType:   flags.String("dbtype", "", "Tipo de base de datos (sqlserver, sybase, mysql, postgres, oracle, sqlite)"),
+		server:   flags.String("server", "localhost", "Servidor de la base de datos"),
+		port:     flags.Int("port", 0, "Puerto de la base de datos (se usará el puerto por defecto según el tipo)"),
+		user:     flags.String("user", "", "Usuario de la base de datos"),
+		password: flags.String("password", "", "Contraseña de la base de datos"),
+		database: flags.String("database", "", "Nombre de la base de datos (servicio para Oracle, ruta del archivo para SQLite)"),
+		schema:   flags.String("schema", "dbo", "Schema, lista separada por comas, patrón glob o * para todos (para bases de datos que lo soportan)"),
+		sslMode:  flags.String("sslmode", "disable", "Modo SSL (para PostgreSQL)"),
+	}
+}
+
+// Indica si se pasó algún parámetro para conectarse a una base en vivo
+func (c *connectionFlags) isSet() bool {
+	return *c.dbType != ""
+}
+
+// Valida los parámetros y devuelve la configuración con los valores por
+// defecto aplicados
+func (c *connectionFlags) config() (Config, error) {
+	config := Config{
+		DBType:   strings.ToLower(*c.dbType),
+		Server:   *c.server,
+		Port:     *c.port,
+		User:     *c.user,
+		Password: *c.password,
+		Database: *c.database,
+		Schema:   *c.schema,
+		SSLMode:  *c.sslMode,
+		Format:   "json",
+	}
+
+	if !isValidDBType(config.DBType) || config.DBType == "mongodb" {
+		return config, fmt.Errorf("tipo de base de datos no válido: %s (válidos: sqlserver, sybase, mysql, postgres, oracle, sqlite)", config.DBType)
+	}
+	if config.Database == "" || (config.DBType != "sqlite" && (config.User == "" || config.Password == "")) {
+		return config, fmt.Errorf("los parámetros dbtype, user, password y database son requeridos")
 	}
+
+	if config.Port == 0 {
+		config.Port = getDefaultPort(config.DBType)
+	}
+	applySchemaDefaults(&config)
+
+	return config, nil
 }
 
 func isValidDBType(dbType string) bool {
-	validTypes := []string{"sqlserver", "sybase", "mysql", "postgres", "mongodb"}
+	validTypes := []string{"sqlserver", "sybase", "mysql", "postgres", "oracle", "sqlite", "mongodb"}
 	for _, t := range validTypes {
 		if dbType == t {
 			return true
@@ -173,6 +500,8 @@ func getDefaultPort(dbType string) int {
 		return 3306
 	case "postgres":
 		return 5432
+	case "oracle":
+		return 1521
 	case "mongodb":
 		return 27017
 	default:
@@ -180,7 +509,35 @@ func getDefaultPort(dbType string) int {
 	}
 }
 
-func processSQLDatabase(config Config) {
+// El esquema se guarda aunque falten objetos; en ese caso devuelve un error
+// para que el programa termine con 1
+func processSQLDatabase(config Config) error {
+	// Extraer el esquema de la base de datos
+	schema, err := extractLiveSchema(config)
+	if err != nil {
+		return fmt.Errorf("error al extraer el esquema: %v", err)
+	}
+
+	// Convertir al dialecto destino si se solicitó
+	schema, err = applyConversion(schema, config)
+	if err != nil {
+		return fmt.Errorf("error al convertir el esquema: %v", err)
+	}
+
+	// Guardar en el formato solicitado
+	err = saveSchemaOutput(schema, config)
+	if err != nil {
+		return fmt.Errorf("error al guardar el esquema: %v", err)
+	}
+
+	fmt.Printf("✅ Esquema guardado en: %s\n", config.Output)
+	fmt.Printf("📊 Total de tablas procesadas: %d\n", len(schema.Tables))
+
+	return incompleteExtractionError(schema.Errors, "objetos")
+}
+
+// Se conecta a la base de datos SQL y extrae su esquema
+func extractLiveSchema(config Config) (*DatabaseSchema, error) {
 	// Crear cadena de conexión según el tipo de BD
 	connectionString := getConnectionString(config)
 
@@ -190,67 +547,123 @@ func processSQLDatabase(config Config) {
 	// Conectar a la base de datos
 	db, err := sql.Open(driverName, connectionString)
 	if err != nil {
-		log.Fatal("Error al conectar a la base de datos:", err)
+		return nil, fmt.Errorf("error al conectar a la base de datos: %v", err)
 	}
 	defer db.Close()
 
+	// Cada worker de -parallel usa una conexión a la vez; el pool no abre más
+	if config.Parallel > 1 {
+		db.SetMaxOpenConns(config.Parallel)
+		db.SetMaxIdleConns(config.Parallel)
+	}
+
 	// Verificar la conexión
 	err = db.Ping()
 	if err != nil {
-		log.Fatal("Error al verificar la conexión:", err)
+		return nil, fmt.Errorf("error al verificar la conexión: %v", err)
 	}
 
-	fmt.Printf("✅ Conexión exitosa a %s\n", strings.ToUpper(config.DBType))
+	fmt.Fprintf(config.progress(), "✅ Conexión exitosa a %s\n", strings.ToUpper(config.DBType))
 
-	// Extraer el esquema de la base de datos
-	schema, err := extractDatabaseSchema(db, config)
+	return extractDatabaseSchema(db, config)
+}
+
+// Procesa un esquema SQL guardado previamente en JSON sin conectarse
+func processSchemaFile(config Config) {
+	schema, err := loadSchemaFromJSONFile(config.Input)
 	if err != nil {
-		log.Fatal("Error al extraer el esquema:", err)
+		log.Fatal("Error al leer el esquema:", err)
 	}
 
-	// Guardar en archivo JSON
-	err = saveToJSONFile(schema, config.Output)
+	fmt.Printf("✅ Esquema cargado desde: %s (%s)\n", config.Input, schema.DBType)
+
+	schema, err = applyConversion(schema, config)
 	if err != nil {
-		log.Fatal("Error al guardar el archivo JSON:", err)
+		log.Fatal("Error al convertir el esquema:", err)
+	}
+
+	err = saveSchemaOutput(schema, config)
+	if err != nil {
+		log.Fatal("Error al guardar el esquema:", err)
 	}
 
 	fmt.Printf("✅ Esquema guardado en: %s\n", config.Output)
 	fmt.Printf("📊 Total de tablas procesadas: %d\n", len(schema.Tables))
 }
 
-func processMongoDB(config Config) {
+// Guarda el esquema como JSON o como script DDL en el dialecto del esquema
+func saveSchemaOutput(schema *DatabaseSchema, config Config) error {
+	if config.Format != "ddl" {
+		return saveToJSONFile(schema, config.Output)
+	}
+
+	ddl, err := generateDDL(schema, schema.DBType)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(config.Output, []byte(ddl), 0644)
+	if err != nil {
+		return fmt.Errorf("error al escribir archivo DDL: %v", err)
+	}
+
+	return nil
+}
+
+func connectMongoDB(config Config) (*mongo.Client, error) {
 	// Crear cadena de conexión para MongoDB
 	connectionString := fmt.Sprintf("mongodb://%s:%s@%s:%d/%s",
 		config.User, config.Password, config.Server, config.Port, config.Database)
 
-	client, err := mongo.Connect(nil, options.Client().ApplyURI(connectionString))
+	clientOptions := options.Client().ApplyURI(connectionString)
+
+	// Cada worker de -parallel usa una conexión a la vez; el pool no abre más
+	if config.Parallel > 1 {
+		clientOptions.SetMaxPoolSize(uint64(config.Parallel))
+	}
+
+	client, err := mongo.Connect(nil, clientOptions)
 	if err != nil {
-		log.Fatal("Error al conectar a MongoDB:", err)
+		return nil, err
 	}
-	defer client.Disconnect(nil)
 
 	// Verificar la conexión
 	err = client.Ping(nil, nil)
 	if err != nil {
-		log.Fatal("Error al verificar la conexión a MongoDB:", err)
+		client.Disconnect(nil)
+		return nil, fmt.Errorf("error al verificar la conexión: %v", err)
 	}
 
-	fmt.Printf("✅ Conexión exitosa a MongoDB\n")
+	fmt.Fprintf(config.progress(), "✅ Conexión exitosa a MongoDB\n")
+
+	return client, nil
+}
+
+// Igual que processSQLDatabase: guarda lo extraído y devuelve un error si
+// faltan colecciones, después de cerrar la conexión
+func processMongoDB(config Config) error {
+	client, err := connectMongoDB(config)
+	if err != nil {
+		return fmt.Errorf("error al conectar a MongoDB: %v", err)
+	}
+	defer client.Disconnect(nil)
 
 	// Extraer el esquema de MongoDB
-	schema, err := extractMongoDBSchema(client, config.Database)
+	schema, err := extractMongoDBSchema(client, config)
 	if err != nil {
-		log.Fatal("Error al extraer el esquema de MongoDB:", err)
+		return fmt.Errorf("error al extraer el esquema de MongoDB: %v", err)
 	}
 
 	// Guardar en archivo JSON
 	err = saveToJSONFile(schema, config.Output)
 	if err != nil {
-		log.Fatal("Error al guardar el archivo JSON:", err)
+		return fmt.Errorf("error al guardar el archivo JSON: %v", err)
 	}
 
 	fmt.Printf("✅ Esquema de MongoDB guardado en: %s\n", config.Output)
 	fmt.Printf("📊 Total de colecciones procesadas: %d\n", len(schema.Collections))
+
+	return incompleteExtractionError(schema.Errors, "colecciones")
 }
 
 func getDriverName(dbType string) string {
@@ -263,6 +676,10 @@ func getDriverName(dbType string) string {
 		return "mysql"
 	case "postgres":
 		return "postgres"
+	case "oracle":
+		return "oracle"
+	case "sqlite":
+		return "sqlite3"
 	default:
 		return ""
 	}
@@ -282,68 +699,297 @@ func getConnectionString(config Config) string {
 	case "postgres":
 		return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 			config.Server, config.Port, config.User, config.Password, config.Database, config.SSLMode)
+	case "oracle":
+		// La base de datos es el nombre del servicio; la URL escapa la contraseña
+		connURL := url.URL{
+			Scheme: "oracle",
+			User:   url.UserPassword(config.User, config.Password),
+			Host:   fmt.Sprintf("%s:%d", config.Server, config.Port),
+			Path:   config.Database,
+		}
+		return connURL.String()
+	case "sqlite":
+		// Solo lectura: evita crear un archivo vacío si la ruta no existe. La
+		// URL escapa los caracteres de la ruta como ? o #
+		connURL := url.URL{
+			Scheme:   "file",
+			Opaque:   (&url.URL{Path: config.Database}).EscapedPath(),
+			RawQuery: "mode=ro",
+		}
+		return connURL.String()
 	default:
 		return ""
 	}
 }
 
 func extractDatabaseSchema(db *sql.DB, config Config) (*DatabaseSchema, error) {
+	// -schema admite una lista, patrones glob o * para todos los schemas
+	schemaNames, err := resolveSchemas(db, config.DBType, config.Schema)
+	if err != nil {
+		return nil, err
+	}
+
 	schema := &DatabaseSchema{
 		DatabaseName: config.Database,
 		DBType:       config.DBType,
-		Schema:       config.Schema,
+		Schema:       defaultExtractedSchema(config, schemaNames),
 		Tables:       []Table{},
 	}
 
-	// Consulta para obtener tablas según el tipo de BD
-	queryTables := getTablesQuery(config.DBType, config.Schema)
+	for _, schemaName := range schemaNames {
+		if len(schemaNames) > 1 {
+			fmt.Fprintf(config.progress(), "📂 Schema: %s\n", schemaName)
+		}
 
-	rowsTables, err := db.Query(queryTables)
-	if err != nil {
-		return nil, fmt.Errorf("error al consultar tablas: %v", err)
+		err := extractSchemaObjects(db, config, schemaName, schema)
+		if err != nil {
+			return nil, err
+		}
 	}
-	defer rowsTables.Close()
 
-	fmt.Printf("🔍 Extrayendo información de tablas...\n")
+	return schema, nil
+}
 
-	for rowsTables.Next() {
-		var tableSchema, tableName string
+// Extrae tablas, vistas, rutinas y secuencias de un schema y las agrega al
+// esquema de la base
+func extractSchemaObjects(db *sql.DB, config Config, schemaName string, schema *DatabaseSchema) error {
+	dbType := config.DBType
 
-		// Manejar diferentes estructuras de resultados según la BD
-		switch config.DBType {
-		case "sqlserver", "sybase":
-			err = rowsTables.Scan(&tableSchema, &tableName)
-		case "mysql":
-			err = rowsTables.Scan(&tableSchema, &tableName)
-		case "postgres":
-			err = rowsTables.Scan(&tableSchema, &tableName)
+	tables, failed, err := extractTables(db, config, schemaName)
+	if err != nil {
+		return err
+	}
+	schema.Tables = append(schema.Tables, tables...)
+	schema.Errors = append(schema.Errors, failed...)
+
+	// Vistas del schema, con sus columnas y su definición
+	views, err := extractViews(db, dbType, schemaName)
+	if err != nil {
+		return fmt.Errorf("error al extraer vistas: %v", err)
+	}
+	schema.Views = append(schema.Views, views...)
+
+	for _, view := range views {
+		kind := "Vista"
+		if view.Materialized {
+			kind = "Vista materializada"
 		}
+		fmt.Fprintf(config.progress(), "  👁️  %s procesada: %s.%s (%d columnas)\n", kind, view.Schema, view.ViewName, len(view.Columns))
+	}
+
+	// Procedimientos almacenados y funciones del schema
+	routines, err := extractRoutines(db, dbType, schemaName)
+	if err != nil {
+		return fmt.Errorf("error al extraer rutinas: %v", err)
+	}
+	schema.Routines = append(schema.Routines, routines...)
 
+	for _, routine := range routines {
+		fmt.Fprintf(config.progress(), "  ⚙️  Rutina procesada: %s.%s (%s, %d parámetros)\n", routine.Schema, routine.RoutineName, routine.Kind, len(routine.Parameters))
+	}
+
+	// Secuencias del schema (PostgreSQL y SQL Server)
+	sequences, err := extractSequences(db, dbType, schemaName)
+	if err != nil {
+		return fmt.Errorf("error al extraer secuencias: %v", err)
+	}
+	schema.Sequences = append(schema.Sequences, sequences...)
+
+	for _, seq := range sequences {
+		fmt.Fprintf(config.progress(), "  🔢 Secuencia procesada: %s.%s\n", seq.Schema, seq.SequenceName)
+	}
+
+	schema.Schemas = append(schema.Schemas, summarizeSchema(schemaName, tables, views, routines, sequences))
+
+	return nil
+}
+
+// Extrae las tablas de un schema con sus columnas, claves, índices,
+// triggers y restricciones. Las tablas excluidas por los filtros se
+// descartan antes de consultar sus columnas. Los motores que lo admiten leen
+// el catálogo de todo el schema con una consulta por tipo de objeto; el resto,
+// o si esas consultas fallan, consultan tabla por tabla. Las tablas que
+// fallan se devuelven aparte y no impiden extraer las demás.
+func extractTables(db *sql.DB, config Config, schemaName string) ([]Table, []ObjectError, error) {
+	dbType := config.DBType
+
+	refs, err := listTables(db, config, schemaName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fmt.Fprintf(config.progress(), "🔍 Extrayendo información de tablas...\n")
+
+	var catalog *schemaCatalog
+	if supportsBulkExtraction(dbType) && len(refs) > 0 {
+		catalog, err = loadSchemaCatalog(db, config, schemaName)
 		if err != nil {
+			fmt.Fprintf(config.progress(), "  ⚠️  No se pudo leer el catálogo del schema %s, se consulta tabla por tabla: %v\n", schemaName, err)
+			catalog = nil
+		}
+	}
+
+	// Con -parallel las tablas se reparten entre varios workers; cada una se
+	// guarda en su posición para conservar el orden de la consulta
+	tables := make([]Table, len(refs))
+
+	errs := runWorkerPool(len(refs), config.Parallel, func(i int) error {
+		ref := refs[i]
+
+		var table Table
+		var err error
+		if catalog != nil {
+			table = catalog.buildTable(config, ref.Schema, ref.Name)
+		} else {
+			table, err = extractTable(db, config, ref.Schema, ref.Name)
+			if err != nil {
+				return err
+			}
+		}
+
+		tables[i] = table
+		fmt.Fprintf(config.progress(), "  📋 Tabla procesada: %s.%s (%d columnas, %d claves foráneas, %d índices, %d triggers, %d checks)\n", table.Schema, table.TableName, len(table.Columns), len(table.ForeignKeys), len(table.Indexes), len(table.Triggers), len(table.CheckConstraints))
+		return nil
+	})
+
+	failed := collectObjectErrors(config.progress(), errs, func(i int) string {
+		return qualifiedTableName(refs[i].Schema, refs[i].Name)
+	})
+	if catalog != nil {
+		failed = append(catalog.errors, failed...)
+	}
+
+	extracted := make([]Table, 0, len(tables))
+	for i, table := range tables {
+		if errs[i] == nil {
+			extracted = append(extracted, table)
+		}
+	}
+
+	return extracted, failed, nil
+}
+
+// Schema y nombre de una tabla a extraer
+type tableRef struct {
+	Schema string
+	Name   string
+}
+
+// Lista las tablas del schema que pasan los filtros. El cursor se cierra
+// antes de consultar cada tabla, ya que no todos los drivers admiten varios
+// resultados abiertos en la misma conexión.
+func listTables(db *sql.DB, config Config, schemaName string) ([]tableRef, error) {
+	rows, err := db.Query(getTablesQuery(config.DBType, schemaName))
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar tablas: %v", err)
+	}
+	defer rows.Close()
+
+	var refs []tableRef
+	for rows.Next() {
+		var ref tableRef
+		if err := rows.Scan(&ref.Schema, &ref.Name); err != nil {
 			return nil, fmt.Errorf("error al escanear tabla: %v", err)
 		}
 
-		// Obtener columnas para esta tabla
-		columns, err := extractTableColumns(db, config.DBType, tableSchema, tableName)
+		if !config.TableFilter.Allows(ref.Name, qualifiedTableName(ref.Schema, ref.Name)) {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre tablas: %v", err)
+	}
+
+	return refs, nil
+}
+
+// Extrae una tabla con una consulta por tipo de objeto
+func extractTable(db *sql.DB, config Config, tableSchema, tableName string) (Table, error) {
+	dbType := config.DBType
+
+	// Las opciones de las tablas de SQLite se leen una vez: WITHOUT ROWID
+	// decide si la clave INTEGER es alias del rowid y si tiene índice propio
+	var withoutRowID, strict bool
+	var err error
+	if dbType == "sqlite" {
+		withoutRowID, strict, err = getSQLiteTableOptions(db, tableName)
 		if err != nil {
-			return nil, fmt.Errorf("error al extraer columnas para tabla %s: %v", tableName, err)
+			return Table{}, fmt.Errorf("error al extraer opciones para tabla %s: %v", tableName, err)
 		}
+	}
 
-		table := Table{
-			TableName: tableName,
-			Schema:    tableSchema,
-			Columns:   columns,
+	// Obtener columnas para esta tabla
+	columns, err := extractTableColumns(db, dbType, tableSchema, tableName, withoutRowID)
+	if err != nil {
+		return Table{}, fmt.Errorf("error al extraer columnas para tabla %s: %v", tableName, err)
+	}
+	columns = filterColumns(columns, config.ColumnFilter, tableSchema, tableName)
+
+	// Sybase consulta la clave primaria aparte; sin ella se sigue igual
+	if dbType == "sybase" {
+		err = addSybasePrimaryKeys(db, tableName, columns)
+		if err != nil {
+			fmt.Fprintf(config.progress(), "  ⚠️  No se pudieron obtener claves primarias para %s: %v\n", tableName, err)
 		}
+	}
 
-		schema.Tables = append(schema.Tables, table)
-		fmt.Printf("  📋 Tabla procesada: %s.%s (%d columnas)\n", tableSchema, tableName, len(columns))
+	// Semilla, incremento y valor actual de las columnas identity
+	err = addIdentityDetails(db, dbType, tableSchema, tableName, columns)
+	if err != nil {
+		fmt.Fprintf(config.progress(), "  ⚠️  No se pudieron obtener los detalles de identidad para %s: %v\n", tableName, err)
 	}
 
-	if err = rowsTables.Err(); err != nil {
-		return nil, fmt.Errorf("error iterando sobre tablas: %v", err)
+	// Nombres de las restricciones DEFAULT
+	err = addDefaultConstraintNames(db, dbType, tableSchema, tableName, columns)
+	if err != nil {
+		fmt.Fprintf(config.progress(), "  ⚠️  No se pudieron obtener las restricciones default para %s: %v\n", tableName, err)
 	}
 
-	return schema, nil
+	// Obtener claves foráneas para esta tabla
+	foreignKeys, err := extractForeignKeys(db, dbType, tableSchema, tableName)
+	if err != nil {
+		return Table{}, fmt.Errorf("error al extraer claves foráneas para tabla %s: %v", tableName, err)
+	}
+
+	// Obtener índices y restricciones únicas para esta tabla
+	indexes, err := extractIndexes(db, dbType, tableSchema, tableName, withoutRowID)
+	if err != nil {
+		return Table{}, fmt.Errorf("error al extraer índices para tabla %s: %v", tableName, err)
+	}
+
+	// Obtener triggers de esta tabla
+	triggers, err := extractTriggers(db, dbType, tableSchema, tableName)
+	if err != nil {
+		return Table{}, fmt.Errorf("error al extraer triggers para tabla %s: %v", tableName, err)
+	}
+
+	// Restricciones CHECK; MySQL anterior a 8.0.16 no tiene catálogo para ellas
+	checks, err := extractCheckConstraints(db, dbType, tableSchema, tableName, columns)
+	if err != nil {
+		fmt.Fprintf(config.progress(), "  ⚠️  No se pudieron obtener las restricciones check para %s: %v\n", tableName, err)
+	}
+
+	table := Table{
+		TableName:        tableName,
+		Schema:           tableSchema,
+		Columns:          columns,
+		ForeignKeys:      foreignKeys,
+		Indexes:          indexes,
+		Triggers:         triggers,
+		CheckConstraints: checks,
+		WithoutRowID:     withoutRowID,
+		Strict:           strict,
+	}
+
+	// Comentarios de la tabla y sus columnas para el diccionario de datos
+	err = addDescriptions(db, dbType, tableSchema, &table)
+	if err != nil {
+		fmt.Fprintf(config.progress(), "  ⚠️  No se pudieron obtener las descripciones para %s: %v\n", tableName, err)
+	}
+
+	return table, nil
 }
 
 func getTablesQuery(dbType string, defaultSchema string) string {
@@ -370,15 +1016,15 @@ func getTablesQuery(dbType string, defaultSchema string) string {
 			ORDER BY schema_name, table_name
 		`, defaultSchema)
 	case "mysql":
-		return `
+		return fmt.Sprintf(`
 			SELECT 
 				TABLE_SCHEMA,
 				TABLE_NAME
 			FROM INFORMATION_SCHEMA.TABLES
 			WHERE TABLE_TYPE = 'BASE TABLE'
-			AND TABLE_SCHEMA = DATABASE()
+			AND TABLE_SCHEMA = '%s'
 			ORDER BY TABLE_SCHEMA, TABLE_NAME
-		`
+		`, defaultSchema)
 	case "postgres":
 		return fmt.Sprintf(`
 			SELECT 
@@ -389,15 +1035,52 @@ func getTablesQuery(dbType string, defaultSchema string) string {
 			AND table_schema = '%s'
 			ORDER BY table_schema, table_name
 		`, defaultSchema)
+	case "oracle":
+		// Se excluyen tablas anidadas, secundarias, de desbordamiento de IOT
+		// y las que están en la papelera de reciclaje
+		return fmt.Sprintf(`
+			SELECT
+				owner,
+				table_name
+			FROM all_tables
+			WHERE owner = '%s'
+			AND nested = 'NO'
+			AND secondary = 'N'
+			AND dropped = 'NO'
+			AND (iot_type IS NULL OR iot_type = 'IOT')
+			ORDER BY owner, table_name
+		`, defaultSchema)
+	case "sqlite":
+		// SQLite no tiene schemas; las tablas internas empiezan con sqlite_
+		return `
+			SELECT
+				'main' AS table_schema,
+				name AS table_name
+			FROM sqlite_master
+			WHERE type = 'table'
+			AND name NOT LIKE 'sqlite\_%' ESCAPE '\'
+			ORDER BY name
+		`
 	default:
 		return ""
 	}
 }
 
-func extractTableColumns(db *sql.DB, dbType, schemaName, tableName string) ([]Column, error) {
+// withoutRowID solo se usa en SQLite: es la opción de la tabla que el llamador
+// ya leyó con getSQLiteTableOptions
+func extractTableColumns(db *sql.DB, dbType, schemaName, tableName string, withoutRowID bool) ([]Column, error) {
 	// Para Sybase, construimos la consulta dinámicamente sin parámetros
 	if dbType == "sybase" {
-		return extractSybaseTableColumns(db, tableName)
+		return extractSybaseTableColumns(db, schemaName, tableName)
+	}
+
+	// SQLite expone las columnas con pragma_table_info
+	if dbType == "sqlite" {
+		return extractSQLiteTableColumns(db, tableName, withoutRowID)
+	}
+
+	if dbType == "oracle" {
+		return extractOracleTableColumns(db, schemaName, tableName)
 	}
 
 	queryColumns := getColumnsQuery(dbType)
@@ -440,7 +1123,7 @@ func extractTableColumns(db *sql.DB, dbType, schemaName, tableName string) ([]Co
 }
 
 // Función específica para extraer columnas de Sybase (sin parámetros)
-func extractSybaseTableColumns(db *sql.DB, tableName string) ([]Column, error) {
+func extractSybaseTableColumns(db *sql.DB, schemaName, tableName string) ([]Column, error) {
 	// Consulta simplificada para Sybase - sin la parte compleja de claves primarias que causa errores
 	query := fmt.Sprintf(`
 		SELECT 
@@ -457,13 +1140,14 @@ func extractSybaseTableColumns(db *sql.DB, tableName string) ([]Column, error) {
 				WHEN c.status & 128 = 128 THEN 1 
 				ELSE 0 
 			END as is_identity,
-			ISNULL(OBJECT_NAME(c.cdefault), '') as default_value,
+			c.cdefault as default_id,
+			ISNULL(OBJECT_NAME(c.cdefault), '') as default_name,
 			0 as is_primary_key  -- Por ahora, no detectamos claves primarias para evitar errores
 		FROM syscolumns c
 		JOIN systypes t ON c.usertype = t.usertype
-		WHERE c.id = object_id('%s')
+		WHERE c.id = object_id('%s.%s')
 		ORDER BY c.colid
-	`, tableName)
+	`, schemaName, tableName)
 
 	rowsColumns, err := db.Query(query)
 	if err != nil {
@@ -472,12 +1156,14 @@ func extractSybaseTableColumns(db *sql.DB, tableName string) ([]Column, error) {
 	defer rowsColumns.Close()
 
 	var columns []Column
+	var defaultIDs []int
 
 	for rowsColumns.Next() {
 		var col Column
 		var isNullable string
 		var length, prec, scale sql.NullInt32
 		var isPrimaryKey, isIdentity int
+		var defaultID sql.NullInt64
 
 		err := rowsColumns.Scan(
 			&col.ColumnName,
@@ -487,7 +1173,8 @@ func extractSybaseTableColumns(db *sql.DB, tableName string) ([]Column, error) {
 			&scale,
 			&isNullable,
 			&isIdentity,
-			&col.DefaultValue,
+			&defaultID,
+			&col.DefaultConstraint,
 			&isPrimaryKey,
 		)
 		if err != nil {
@@ -510,29 +1197,44 @@ func extractSybaseTableColumns(db *sql.DB, tableName string) ([]Column, error) {
 		}
 
 		columns = append(columns, col)
+		defaultIDs = append(defaultIDs, int(defaultID.Int64))
 	}
 
 	if err = rowsColumns.Err(); err != nil {
 		return nil, fmt.Errorf("error iterando sobre columnas: %v", err)
 	}
+	rowsColumns.Close()
 
-	// Intentar obtener información de claves primarias por separado
-	primaryKeys, err := getSybasePrimaryKeys(db, tableName)
-	if err != nil {
-		// Si hay error, simplemente continuamos sin información de PKs
-		fmt.Printf("  ⚠️  No se pudieron obtener claves primarias para %s: %v\n", tableName, err)
-	} else {
-		// Actualizar las columnas que son claves primarias
-		for i, col := range columns {
-			if _, isPK := primaryKeys[col.ColumnName]; isPK {
-				columns[i].IsPrimaryKey = true
-			}
+	// cdefault es el id del objeto default; su valor está en syscomments
+	for i, defaultID := range defaultIDs {
+		if defaultID == 0 {
+			continue
+		}
+		columns[i].DefaultValue, err = getSybaseDefaultValue(db, defaultID)
+		if err != nil {
+			return nil, fmt.Errorf("error al extraer el default de la columna %s: %v", columns[i].ColumnName, err)
 		}
 	}
 
 	return columns, nil
 }
 
+// Marca las columnas de la clave primaria; en Sybase se consultan por
+// separado de las columnas
+func addSybasePrimaryKeys(db *sql.DB, tableName string, columns []Column) error {
+	primaryKeys, err := getSybasePrimaryKeys(db, tableName)
+	if err != nil {
+		return err
+	}
+
+	for i, col := range columns {
+		if primaryKeys[col.ColumnName] {
+			columns[i].IsPrimaryKey = true
+		}
+	}
+	return nil
+}
+
 // Función separada para obtener claves primarias en Sybase
 func getSybasePrimaryKeys(db *sql.DB, tableName string) (map[string]bool, error) {
 	primaryKeys := make(map[string]bool)
@@ -625,6 +1327,7 @@ func getColumnsQuery(dbType string) string {
 				c.CHARACTER_MAXIMUM_LENGTH,
 				c.NUMERIC_PRECISION,
 				c.NUMERIC_SCALE,
+				c.DATETIME_PRECISION,
 				CASE WHEN pk.COLUMN_NAME IS NOT NULL THEN 1 ELSE 0 END AS IS_PRIMARY_KEY,
 				COLUMNPROPERTY(OBJECT_ID(c.TABLE_SCHEMA + '.' + c.TABLE_NAME), c.COLUMN_NAME, 'IsIdentity') AS IS_IDENTITY,
 				COALESCE(c.COLUMN_DEFAULT, '') AS COLUMN_DEFAULT
@@ -654,9 +1357,11 @@ func getColumnsQuery(dbType string) string {
 				CHARACTER_MAXIMUM_LENGTH,
 				NUMERIC_PRECISION,
 				NUMERIC_SCALE,
+				DATETIME_PRECISION,
 				CASE WHEN COLUMN_KEY = 'PRI' THEN 1 ELSE 0 END AS IS_PRIMARY_KEY,
 				CASE WHEN EXTRA LIKE '%auto_increment%' THEN 1 ELSE 0 END AS IS_IDENTITY,
-				COALESCE(COLUMN_DEFAULT, '') AS COLUMN_DEFAULT
+				COALESCE(COLUMN_DEFAULT, '') AS COLUMN_DEFAULT,
+				CASE WHEN COLUMN_TYPE LIKE '%unsigned%' THEN 1 ELSE 0 END AS IS_UNSIGNED
 			FROM INFORMATION_SCHEMA.COLUMNS
 			WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
 			ORDER BY ORDINAL_POSITION
@@ -665,11 +1370,18 @@ func getColumnsQuery(dbType string) string {
 		return `
 			SELECT 
 				column_name,
-				data_type,
+				-- ARRAY y USER-DEFINED no dicen el tipo; format_type da p. ej.
+				-- integer[] o el nombre del enum, igual que en el DDL
+				CASE
+					WHEN c.data_type IN ('ARRAY', 'USER-DEFINED')
+					THEN format_type(format('%I.%I', c.udt_schema, c.udt_name)::regtype, NULL)
+					ELSE c.data_type
+				END AS data_type,
 				is_nullable,
 				character_maximum_length,
 				numeric_precision,
 				numeric_scale,
+				datetime_precision,
 				CASE 
 					WHEN (SELECT COUNT(*) 
 						  FROM information_schema.key_column_usage k
@@ -684,7 +1396,7 @@ func getColumnsQuery(dbType string) string {
 					ELSE 0 
 				END AS is_primary_key,
 				CASE 
-					WHEN column_default LIKE 'nextval%' THEN 1 
+					WHEN column_default LIKE 'nextval%' OR is_identity = 'YES' THEN 1 
 					ELSE 0 
 				END AS is_identity,
 				COALESCE(column_default, '') AS column_default
@@ -698,49 +1410,41 @@ func getColumnsQuery(dbType string) string {
 	}
 }
 
-func scanColumn(rows *sql.Rows, dbType string) (Column, error) {
+func scanColumn(rows *sql.Rows, dbType string, prefix ...interface{}) (Column, error) {
 	var col Column
 	var isNullable string
-	var charMaxLength, numericPrecision, numericScale sql.NullInt32
-	var isPrimaryKey, isIdentity int
+	var charMaxLength, numericPrecision, numericScale, datetimePrecision sql.NullInt32
+	var isPrimaryKey, isIdentity, isUnsigned int
+
+	// prefix recibe las columnas adicionales al inicio de la fila, como el
+	// nombre de la tabla en las consultas de todo el schema
+	dest := append(prefix,
+		&col.ColumnName,
+		&col.DataType,
+		&isNullable,
+		&charMaxLength,
+		&numericPrecision,
+		&numericScale,
+		&datetimePrecision,
+		&isPrimaryKey,
+		&isIdentity,
+		&col.DefaultValue,
+	)
+
+	// DATA_TYPE no distingue los enteros sin signo; se leen de COLUMN_TYPE
+	if dbType == "mysql" {
+		dest = append(dest, &isUnsigned)
+	}
 
-	switch dbType {
-	case "sqlserver":
-		err := rows.Scan(
-			&col.ColumnName,
-			&col.DataType,
-			&isNullable,
-			&charMaxLength,
-			&numericPrecision,
-			&numericScale,
-			&isPrimaryKey,
-			&isIdentity,
-			&col.DefaultValue,
-		)
-		if err != nil {
-			return col, err
-		}
-	case "mysql", "postgres":
-		err := rows.Scan(
-			&col.ColumnName,
-			&col.DataType,
-			&isNullable,
-			&charMaxLength,
-			&numericPrecision,
-			&numericScale,
-			&isPrimaryKey,
-			&isIdentity,
-			&col.DefaultValue,
-		)
-		if err != nil {
-			return col, err
-		}
+	if err := rows.Scan(dest...); err != nil {
+		return col, err
 	}
 
 	// Convertir valores comunes
 	col.IsNullable = isNullable
 	col.IsPrimaryKey = (isPrimaryKey == 1)
 	col.IsIdentity = (isIdentity == 1)
+	col.IsUnsigned = (isUnsigned == 1)
 
 	if charMaxLength.Valid {
 		col.MaxLength = int(charMaxLength.Int32)
@@ -751,43 +1455,93 @@ func scanColumn(rows *sql.Rows, dbType string) (Column, error) {
 	if numericScale.Valid {
 		col.Scale = int(numericScale.Int32)
 	}
+	if datetimePrecision.Valid {
+		setDatetimePrecision(&col, int(datetimePrecision.Int32))
+	}
 
 	return col, nil
 }
 
-func extractMongoDBSchema(client *mongo.Client, databaseName string) (*MongoSchema, error) {
+func extractMongoDBSchema(client *mongo.Client, config Config) (*MongoSchema, error) {
+	databaseName := config.Database
 	schema := &MongoSchema{
 		DatabaseName: databaseName,
 		DBType:       "mongodb",
 		Collections:  []MongoCollection{},
 	}
 
-	// Obtener lista de colecciones
-	collections, err := client.Database(databaseName).ListCollectionNames(nil, nil)
+	// Obtener las especificaciones completas de las colecciones
+	specs, err := listMongoCollectionSpecs(client.Database(databaseName))
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Printf("🔍 Extrayendo información de colecciones...\n")
-
-	for _, collName := range collections {
-		fmt.Printf("  📁 Procesando colección: %s\n", collName)
+	fmt.Fprintf(config.progress(), "🔍 Extrayendo información de colecciones...\n")
 
-		collection := MongoCollection{
-			CollectionName: collName,
-			DatabaseName:   databaseName,
-			Indexes:        []MongoIndex{},
+	var selected []mongoCollectionSpec
+	for _, spec := range specs {
+		if config.TableFilter.Allows(spec.Name, databaseName+"."+spec.Name) {
+			selected = append(selected, spec)
 		}
+	}
+
+	// Con -parallel el muestreo de las colecciones se reparte entre varios
+	// workers; cada una se guarda en su posición para conservar el orden
+	collections := make([]MongoCollection, len(selected))
+
+	errs := runWorkerPool(len(selected), config.Parallel, func(i int) error {
+		collection, err := extractMongoCollection(client.Database(databaseName), config, selected[i])
+		collections[i] = collection
+		return err
+	})
 
-		// Aquí podrías agregar lógica para extraer índices y documentos de muestra
-		// Por simplicidad, solo agregamos la colección básica
+	schema.Errors = collectObjectErrors(config.progress(), errs, func(i int) string {
+		return selected[i].Name
+	})
 
-		schema.Collections = append(schema.Collections, collection)
+	for i, collection := range collections {
+		if errs[i] == nil {
+			schema.Collections = append(schema.Collections, collection)
+		}
 	}
 
 	return schema, nil
 }
 
+// Extrae una colección con sus índices y, si se pidió, su esquema inferido
+func extractMongoCollection(database *mongo.Database, config Config, spec mongoCollectionSpec) (MongoCollection, error) {
+	collName := spec.Name
+	fmt.Fprintf(config.progress(), "  📁 Procesando colección: %s (%s)\n", collName, spec.Type)
+
+	collection := newMongoCollection(database.Name(), spec)
+
+	// Extraer índices de la colección (las vistas no tienen índices propios)
+	if collection.CollectionType != "view" {
+		indexes, err := extractMongoIndexes(database.Collection(collName))
+		if err != nil {
+			return collection, fmt.Errorf("error al extraer índices para colección %s: %v", collName, err)
+		}
+		collection.Indexes = indexes
+	}
+
+	// Inferir el esquema a partir de una muestra de documentos
+	if config.SampleSize > 0 {
+		inferred, sample, err := inferMongoCollectionSchema(database.Collection(collName), config.SampleSize)
+		if err != nil {
+			return collection, fmt.Errorf("error al inferir esquema para colección %s: %v", collName, err)
+		}
+		collection.InferredSchema = inferred
+		collection.SampleDocument = sample
+		fmt.Fprintf(config.progress(), "    🧪 %s: %d documentos analizados, %d campos de primer nivel\n", collName, inferred.DocumentCount, len(inferred.Fields))
+
+		if config.Validator {
+			collection.SuggestedValidator = buildMongoValidator(inferred, config.ValidatorOptions)
+		}
+	}
+
+	return collection, nil
+}
+
 func saveToJSONFile(data interface{}, filename string) error {
 	file, err := os.Create(filename)
 	if err != nil {
@@ -806,6 +1560,21 @@ func saveToJSONFile(data interface{}, filename string) error {
 	return nil
 }
 
+func loadSchemaFromJSONFile(filename string) (*DatabaseSchema, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer archivo: %v", err)
+	}
+
+	var schema DatabaseSchema
+	err = json.Unmarshal(data, &schema)
+	if err != nil {
+		return nil, fmt.Errorf("error al decodificar JSON: %v", err)
+	}
+
+	return &schema, nil
+}
+
 func printHelp() {
 	fmt.Println("🚀 Extractor de Esquema de Base de Datos Multiplataforma")
 	fmt.Println("========================================================")
@@ -813,23 +1582,75 @@ func printHelp() {
 	fmt.Println("y las guarda en un archivo JSON.")
 	fmt.Println()
 	fmt.Println("📋 Parámetros:")
-	fmt.Println("  -dbtype    Tipo de base de datos (sqlserver, sybase, mysql, postgres, mongodb) *REQUERIDO*")
+	fmt.Println("  -dbtype    Tipo de base de datos (sqlserver, sybase, mysql, postgres, oracle, sqlite, mongodb) *REQUERIDO*")
 	fmt.Println("  -server    Servidor de la base de datos (default: localhost)")
 	fmt.Println("  -port      Puerto de la base de datos (default: según el tipo de BD)")
-	fmt.Println("  -user      Usuario de la base de datos *REQUERIDO*")
-	fmt.Println("  -password  Contraseña de la base de datos *REQUERIDO*")
-	fmt.Println("  -database  Nombre de la base de datos *REQUERIDO*")
-	fmt.Println("  -schema    Schema por defecto (default: dbo)")
-	fmt.Println("  -output    Archivo de salida JSON (default: database_schema.json)")
+	fmt.Println("  -user      Usuario de la base de datos *REQUERIDO* (excepto SQLite)")
+	fmt.Println("  -password  Contraseña de la base de datos *REQUERIDO* (excepto SQLite)")
+	fmt.Println("  -database  Nombre de la base de datos, servicio Oracle o ruta del archivo SQLite *REQUERIDO*")
+	fmt.Println("  -schema    Schema, owner en Oracle; admite listas (ventas,compras), patrones (ventas_*)")
+	fmt.Println("             o * para todos los schemas salvo los de sistema (default: dbo)")
+	fmt.Println("  -output    Archivo de salida (default: database_schema.json, o database_schema.sql con -format ddl)")
+	fmt.Println("  -format    Formato de salida: json o ddl (CREATE TABLE en el dialecto de origen) (default: json)")
+	fmt.Println("  -input     Archivo JSON de un esquema extraído previamente; no requiere conexión")
+	fmt.Println("  -target    Convertir el esquema a otro dialecto: sqlserver, sybase, mysql o postgres")
+	fmt.Println("  -typemap   Archivo JSON con reglas de conversión: {\"types\": {...}, \"columns\": {...}, \"schemas\": {...}}")
+	fmt.Println("  -report    Archivo JSON para el informe de conversión (tipos por columna y pérdidas)")
 	fmt.Println("  -sslmode   Modo SSL para PostgreSQL (default: disable)")
+	fmt.Println("  -sample    Documentos a muestrear por colección para inferir el esquema en MongoDB (default: 0, desactivado)")
+	fmt.Println("  -validator Generar un validador $jsonSchema por colección en MongoDB (requiere -sample)")
+	fmt.Println("  -requiredratio  Presencia mínima para marcar un campo como requerido (default: 1.0)")
+	fmt.Println("  -enummax   Máximo de valores distintos para generar un enum (default: 10)")
+	fmt.Println("  -include   Tablas o colecciones a incluir, separadas por coma; admite patrones glob (ventas_*)")
+	fmt.Println("             y expresiones regulares entre barras (/^cli_\\d+$/); se comparan con tabla y schema.tabla")
+	fmt.Println("  -exclude   Tablas o colecciones a excluir, con el mismo formato que -include (audit_*,tmp_*)")
+	fmt.Println("  -includecolumns  Columnas a incluir; se comparan con columna, tabla.columna y schema.tabla.columna")
+	fmt.Println("  -excludecolumns  Columnas a excluir, con el mismo formato que -includecolumns (*.password)")
+	fmt.Println("  -parallel  Tablas o colecciones a procesar en paralelo; es también el máximo de conexiones")
+	fmt.Println("             abiertas. Útil en Sybase, Oracle y SQLite, y al muestrear MongoDB (default: 1)")
+	fmt.Println("  -alldatabases  Extraer todas las bases del servidor salvo las de sistema (SQL Server, Sybase,")
+	fmt.Println("             MySQL, PostgreSQL y MongoDB); -database es la base para conectarse")
+	fmt.Println("  -dbinclude Patrones de bases a incluir, separados por coma (ventas_*,rrhh)")
+	fmt.Println("  -dbexclude Patrones de bases a excluir, separados por coma (*_test)")
+	fmt.Println("  -combined  Guardar todas las bases en un catálogo JSON; sin él se escribe un archivo por")
+	fmt.Println("             base agregando su nombre a -output (esquema.json -> esquema_ventas.json)")
 	fmt.Println("  -help      Mostrar esta ayuda")
 	fmt.Println()
+	fmt.Println("🔍 Comparación de esquemas:")
+	fmt.Println("  ./extractor diff [-classify] [-format text|json|markdown] [-output archivo] base.json nuevo.json")
+	fmt.Println("  Compara dos archivos JSON extraídos (SQL o MongoDB). Termina con código 0 si son")
+	fmt.Println("  iguales, 1 si hay diferencias y 2 ante un error.")
+	fmt.Println("  -classify agrega el impacto de cada cambio: additive, compatible o breaking.")
+	fmt.Println()
+	fmt.Println("🛠️  Scripts de migración:")
+	fmt.Println("  ./extractor migrate [-allowdestructive] [-output migration.sql] base.json destino.json")
+	fmt.Println("  ./extractor migrate [-allowdestructive] -dbtype ... -user ... -password ... -database ... base.json")
+	fmt.Println("  Genera las sentencias para llevar una base con el esquema base al destino (archivo o")
+	fmt.Println("  base en vivo). DROP TABLE, DROP COLUMN y reducciones de tipo quedan comentados salvo")
+	fmt.Println("  que se indique -allowdestructive.")
+	fmt.Println()
+	fmt.Println("🚨 Detección de desvíos:")
+	fmt.Println("  ./extractor check [-ignore ignorar.json] [-format text|json|markdown] -dbtype ... -database ... base.json")
+	fmt.Println("  Extrae el esquema en vivo y lo compara con la línea base. Código de salida 0 sin")
+	fmt.Println("  desvíos, 1 con desvíos compatibles, 2 con desvíos incompatibles y 3 ante un error.")
+	fmt.Println(`  ignorar.json: {"tables": ["tmp_*"], "columns": ["*.updated_at"], "attributes": ["defaultValue", "indexes"]}`)
+	fmt.Println()
 	fmt.Println("💡 Ejemplos de uso:")
 	fmt.Println("  SQL Server: ./extractor -dbtype sqlserver -user sa -password secret -database MiDB -schema dbo -output esquema.json")
 	fmt.Println("  PostgreSQL: ./extractor -dbtype postgres -user postgres -password pass -database MiDB -schema public -output esquema.json")
 	fmt.Println("  MySQL:      ./extractor -dbtype mysql -user root -password pass -database MiDB -output esquema.json")
+	fmt.Println("  Schemas:    ./extractor -dbtype postgres -user postgres -password pass -database MiDB -schema \"public,ventas_*\" -output esquema.json")
 	fmt.Println("  Sybase:     ./extractor -dbtype sybase -user sa -password secret -database MiDB -schema dbo -output esquema.json")
+	fmt.Println("  Oracle:     ./extractor -dbtype oracle -user hr -password secret -database ORCLPDB1 -schema HR -output esquema.json")
+	fmt.Println("  SQLite:     ./extractor -dbtype sqlite -database ./datos.db -output esquema.json")
 	fmt.Println("  MongoDB:    ./extractor -dbtype mongodb -user admin -password pass -database MiDB -output esquema.json")
+	fmt.Println("  Muestreo:   ./extractor -dbtype mongodb -user admin -password pass -database MiDB -sample 1000 -output esquema.json")
+	fmt.Println("  Validador:  ./extractor -dbtype mongodb -user admin -password pass -database MiDB -sample 1000 -validator -requiredratio 0.95 -output esquema.json")
+	fmt.Println("  Paralelo:   ./extractor -dbtype sybase -user sa -password secret -database MiDB -schema dbo -parallel 8 -output esquema.json")
+	fmt.Println("  Servidor:   ./extractor -dbtype sqlserver -user sa -password secret -alldatabases -dbexclude \"*_test\" -combined -output catalogo.json")
+	fmt.Println("  DDL:        ./extractor -dbtype postgres -user postgres -password pass -database MiDB -schema public -format ddl -output esquema.sql")
+	fmt.Println("  DDL (JSON): ./extractor -input esquema.json -format ddl -output esquema.sql")
+	fmt.Println("  Conversión: ./extractor -input sybase.json -target postgres -typemap tipos.json -report informe.json -format ddl -output postgres.sql")
 	fmt.Println("  Ayuda:      ./extractor -help")
 	fmt.Println()
 	fmt.Println("🔧 Valores por defecto:")
@@ -837,5 +1658,7 @@ func printHelp() {
 	fmt.Println("  Sybase:     puerto 5000, schema dbo")
 	fmt.Println("  MySQL:      puerto 3306, schema nombre_de_la_base")
 	fmt.Println("  PostgreSQL: puerto 5432, schema public")
+	fmt.Println("  Oracle:     puerto 1521, schema el usuario conectado")
+	fmt.Println("  SQLite:     archivo local, schema main")
 	fmt.Println("  MongoDB:    puerto 27017")
 }
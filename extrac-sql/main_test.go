@@ -0,0 +1,182 @@
+package main
+
+import (
+	"database/sql"
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Crea una base SQLite temporal con las sentencias indicadas
+func createTestSQLiteDB(t *testing.T, statements ...string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "prueba.db")
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("no se pudo crear la base: %v", err)
+	}
+	defer db.Close()
+
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("error en %q: %v", stmt, err)
+		}
+	}
+	return path
+}
+
+func extractTestSQLiteSchema(t *testing.T, path string, parallel int) *DatabaseSchema {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("no se pudo abrir la base: %v", err)
+	}
+	defer db.Close()
+
+	schema, err := extractDatabaseSchema(db, Config{DBType: "sqlite", Database: path, Parallel: parallel})
+	if err != nil {
+		t.Fatalf("extractDatabaseSchema: %v", err)
+	}
+	return schema
+}
+
+var testSQLiteSchema = []string{
+	`CREATE TABLE clientes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		nombre VARCHAR(100) NOT NULL CHECK (length(nombre) > 0),
+		email TEXT UNIQUE,
+		alta DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`,
+	`CREATE TABLE pedidos (
+		id INTEGER PRIMARY KEY,
+		cliente_id INTEGER NOT NULL REFERENCES clientes(id) ON DELETE CASCADE,
+		total DECIMAL(12,2)
+	)`,
+	`CREATE INDEX ix_pedidos_cliente ON pedidos(cliente_id DESC)`,
+	`CREATE TABLE etiquetas (codigo TEXT PRIMARY KEY, descripcion TEXT) WITHOUT ROWID`,
+	`CREATE TRIGGER tr_clientes AFTER INSERT ON clientes BEGIN SELECT 1; END`,
+	`CREATE VIEW v_clientes AS SELECT id, nombre FROM clientes`,
+}
+
+func TestExtractDatabaseSchemaSQLite(t *testing.T) {
+	schema := extractTestSQLiteSchema(t, createTestSQLiteDB(t, testSQLiteSchema...), 1)
+
+	if schema.DBType != "sqlite" || schema.Schema != "main" {
+		t.Errorf("dbType/schema = %s/%s, se esperaba sqlite/main", schema.DBType, schema.Schema)
+	}
+	if len(schema.Errors) != 0 {
+		t.Errorf("errores inesperados: %v", schema.Errors)
+	}
+
+	tables := make(map[string]Table)
+	for _, table := range schema.Tables {
+		tables[table.TableName] = table
+	}
+	for _, name := range []string{"clientes", "pedidos", "etiquetas"} {
+		if _, ok := tables[name]; !ok {
+			t.Fatalf("falta la tabla %s en %v", name, schema.Tables)
+		}
+	}
+	if _, ok := tables["sqlite_sequence"]; ok {
+		t.Errorf("se extrajo la tabla interna sqlite_sequence")
+	}
+
+	clientes := tables["clientes"]
+	wantColumns := []string{"id", "nombre", "email", "alta"}
+	var gotColumns []string
+	for _, col := range clientes.Columns {
+		gotColumns = append(gotColumns, col.ColumnName)
+	}
+	if !reflect.DeepEqual(gotColumns, wantColumns) {
+		t.Errorf("columnas de clientes = %v, se esperaba %v", gotColumns, wantColumns)
+	}
+
+	id, nombre := clientes.Columns[0], clientes.Columns[1]
+	if !id.IsPrimaryKey || !id.IsIdentity {
+		t.Errorf("clientes.id: isPrimaryKey=%v isIdentity=%v, se esperaba true/true", id.IsPrimaryKey, id.IsIdentity)
+	}
+	if nombre.IsNullable != "NO" || nombre.MaxLength != 100 {
+		t.Errorf("clientes.nombre: isNullable=%s maxLength=%d, se esperaba NO/100", nombre.IsNullable, nombre.MaxLength)
+	}
+	if len(clientes.CheckConstraints) != 1 || clientes.CheckConstraints[0].Expression != "length(nombre) > 0" {
+		t.Errorf("checks de clientes = %+v", clientes.CheckConstraints)
+	}
+	if len(clientes.Triggers) != 1 || clientes.Triggers[0].TriggerName != "tr_clientes" {
+		t.Errorf("triggers de clientes = %+v", clientes.Triggers)
+	}
+
+	pedidos := tables["pedidos"]
+	if len(pedidos.ForeignKeys) != 1 {
+		t.Fatalf("claves foráneas de pedidos = %+v", pedidos.ForeignKeys)
+	}
+	fk := pedidos.ForeignKeys[0]
+	if fk.ReferencedTable != "clientes" || !reflect.DeepEqual(fk.Columns, []string{"cliente_id"}) || fk.OnDelete != "CASCADE" {
+		t.Errorf("clave foránea de pedidos = %+v", fk)
+	}
+
+	var index *Index
+	for i := range pedidos.Indexes {
+		if pedidos.Indexes[i].IndexName == "ix_pedidos_cliente" {
+			index = &pedidos.Indexes[i]
+		}
+	}
+	if index == nil {
+		t.Fatalf("falta el índice ix_pedidos_cliente en %+v", pedidos.Indexes)
+	}
+	if index.IsUnique || len(index.Columns) != 1 || index.Columns[0] != (IndexColumn{ColumnName: "cliente_id", Order: "DESC"}) {
+		t.Errorf("índice ix_pedidos_cliente = %+v", *index)
+	}
+
+	if !tables["etiquetas"].WithoutRowID {
+		t.Errorf("etiquetas debería ser WITHOUT ROWID")
+	}
+
+	if len(schema.Views) != 1 || schema.Views[0].ViewName != "v_clientes" || len(schema.Views[0].Columns) != 2 {
+		t.Errorf("vistas = %+v", schema.Views)
+	}
+}
+
+// Con -parallel el resultado debe ser el mismo y en el mismo orden
+func TestExtractDatabaseSchemaParallel(t *testing.T) {
+	path := createTestSQLiteDB(t, testSQLiteSchema...)
+
+	sequential := extractTestSQLiteSchema(t, path, 1)
+	parallel := extractTestSQLiteSchema(t, path, 4)
+
+	if !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("el esquema extraído con -parallel 4 difiere del secuencial")
+	}
+}
+
+func TestProcessSQLDatabaseSQLite(t *testing.T) {
+	path := createTestSQLiteDB(t, testSQLiteSchema...)
+	output := filepath.Join(t.TempDir(), "esquema.json")
+
+	err := processSQLDatabase(Config{DBType: "sqlite", Database: path, Output: output,
+		Format: "json", Parallel: 1, Progress: io.Discard})
+	if err != nil {
+		t.Fatalf("processSQLDatabase: %v", err)
+	}
+
+	schema, err := loadSchemaFromJSONFile(output)
+	if err != nil {
+		t.Fatalf("no se pudo leer el esquema guardado: %v", err)
+	}
+	if len(schema.Tables) != 3 {
+		t.Errorf("tablas guardadas = %d, se esperaban 3", len(schema.Tables))
+	}
+}
+
+func TestIncompleteExtractionError(t *testing.T) {
+	if err := incompleteExtractionError(nil, "objetos"); err != nil {
+		t.Errorf("sin fallos se esperaba nil, se obtuvo %v", err)
+	}
+
+	failed := []ObjectError{{Object: "main.clientes", Error: "tabla bloqueada"}}
+	if err := incompleteExtractionError(failed, "objetos"); err == nil {
+		t.Error("con fallos se esperaba un error")
+	}
+}
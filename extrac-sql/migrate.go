@@ -0,0 +1,599 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// Paso de un script de migración. Los pasos destructivos (eliminar tablas o
+// columnas, reducir tipos) solo se emiten con -allowdestructive.
+type MigrationStep struct {
+	Table       string
+	Description string
+	Statements  []string
+	Notes       []string
+	Destructive bool
+}
+
+// Subcomando migrate: genera el script que lleva una base con el esquema
+// base al esquema destino, leído de un archivo o de una base en vivo
+func runMigrateCommand(args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	output := flags.String("output", "migration.sql", "Archivo del script de migración")
+	allowDestructive := flags.Bool("allowdestructive", false, "Incluir pasos que pueden perder datos (DROP TABLE, DROP COLUMN, reducir tipos)")
+	conn := addConnectionFlags(flags)
+	flags.Usage = func() {
+		fmt.Println("Uso: ./extractor migrate [-allowdestructive] [-output archivo] base.json destino.json")
+		fmt.Println("     ./extractor migrate [-allowdestructive] [-output archivo] -dbtype ... -database ... base.json")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() < 1 || flags.NArg() > 2 || (flags.NArg() == 1) != conn.isSet() {
+		flags.Usage()
+		return 1
+	}
+
+	base, err := loadSchemaFromJSONFile(flags.Arg(0))
+	if err != nil {
+		fmt.Println("Error al leer el esquema base:", err)
+		return 1
+	}
+
+	var target *DatabaseSchema
+	targetName := flags.Arg(1)
+	if flags.NArg() == 2 {
+		target, err = loadSchemaFromJSONFile(targetName)
+	} else {
+		var config Config
+		config, err = conn.config()
+		if err == nil {
+			targetName = fmt.Sprintf("%s://%s:%d/%s", config.DBType, config.Server, config.Port, config.Database)
+			target, err = extractLiveSchema(config)
+		}
+		// Una tabla que no se pudo extraer generaría un DROP TABLE
+		if err == nil {
+			err = joinObjectErrors(target.Errors)
+		}
+	}
+	if err != nil {
+		fmt.Println("Error al obtener el esquema destino:", err)
+		return 1
+	}
+
+	if base.DBType != target.DBType {
+		fmt.Printf("Error: Los esquemas son de motores distintos (%s y %s); convertir primero con -target\n", base.DBType, target.DBType)
+		return 1
+	}
+	if !isDDLDialect(target.DBType) {
+		fmt.Printf("Error: No se generan migraciones para %s (válidos: %s)\n", target.DBType, strings.Join(ddlDialects, ", "))
+		return 1
+	}
+
+	diff := diffDatabaseSchemas(base, target)
+	diff.Base = flags.Arg(0)
+	diff.Target = targetName
+
+	steps := generateMigrationSteps(diff, target.DBType)
+	script := renderMigrationScript(diff, steps, target.DBType, *allowDestructive)
+
+	err = os.WriteFile(*output, []byte(script), 0644)
+	if err != nil {
+		fmt.Printf("Error al escribir el script: %v\n", err)
+		return 1
+	}
+
+	skipped := 0
+	for _, step := range steps {
+		if step.Destructive && !*allowDestructive {
+			skipped++
+		}
+	}
+
+	fmt.Printf("✅ Script de migración guardado en: %s\n", *output)
+	fmt.Printf("📊 Pasos: %d (%d destructivos omitidos)\n", len(steps), skipped)
+	if skipped > 0 {
+		fmt.Println("⚠️  Los pasos destructivos se incluyen como comentario; usar -allowdestructive para aplicarlos")
+	}
+
+	return 0
+}
+
+// Ordena los pasos para que cada sentencia encuentre la estructura que
+// necesita: renombrar tablas, quitar claves primarias que cambian, crear
+// tablas, agregar y modificar columnas, volver a crear las claves primarias
+// y por último eliminar columnas y tablas.
+func generateMigrationSteps(diff *SchemaDiff, dialect string) []MigrationStep {
+	var renames, dropKeys, creates, addColumns, alterColumns, addKeys, dropColumns, dropTables []MigrationStep
+
+	for _, td := range diff.Tables {
+		switch td.Change {
+		case "added":
+			creates = append(creates, MigrationStep{
+				Table:       qualifiedTableName(td.Schema, td.TableName),
+				Description: "Crear tabla " + qualifiedTableName(td.Schema, td.TableName),
+				Statements:  []string{generateCreateTable(*td.NewTable, dialect)},
+			})
+			continue
+		case "removed":
+			dropTables = append(dropTables, MigrationStep{
+				Table:       qualifiedTableName(td.Schema, td.TableName),
+				Description: "Eliminar tabla " + qualifiedTableName(td.Schema, td.TableName),
+				Statements:  []string{"DROP TABLE " + quoteTableName(td.Schema, td.TableName, dialect)},
+				Destructive: true,
+			})
+			continue
+		case "renamed":
+			renames = append(renames, renameTableStep(td.OldTable, td.NewTable, dialect))
+		}
+
+		table := *td.NewTable
+		tableName := quoteTableName(table.Schema, table.TableName, dialect)
+		qualified := qualifiedTableName(table.Schema, table.TableName)
+
+		for _, c := range td.Changes {
+			if c.Attribute != "primaryKey" {
+				continue
+			}
+			if step, ok := dropPrimaryKeyStep(*td.OldTable, table, dialect); ok {
+				dropKeys = append(dropKeys, step)
+			}
+			if c.New != "" {
+				addKeys = append(addKeys, MigrationStep{
+					Table:       qualified,
+					Description: fmt.Sprintf("Crear clave primaria de %s (%s)", qualified, c.New),
+					Statements:  []string{"ALTER TABLE " + tableName + " ADD " + generatePrimaryKeyConstraint(table, dialect)},
+				})
+			}
+		}
+
+		for _, cd := range td.Columns {
+			switch cd.Change {
+			case "added":
+				addColumns = append(addColumns, addColumnStep(table, *cd.New, dialect))
+			case "removed":
+				dropColumns = append(dropColumns, dropColumnStep(table, *cd.Old, dialect))
+			case "modified":
+				if step, ok := alterColumnStep(table, cd, dialect); ok {
+					alterColumns = append(alterColumns, step)
+				}
+			}
+		}
+	}
+
+	var steps []MigrationStep
+	for _, group := range [][]MigrationStep{renames, dropKeys, creates, addColumns, alterColumns, addKeys, dropColumns, dropTables} {
+		steps = append(steps, group...)
+	}
+	return steps
+}
+
+func renameTableStep(oldTable, newTable *Table, dialect string) MigrationStep {
+	step := MigrationStep{
+		Table: qualifiedTableName(newTable.Schema, newTable.TableName),
+		Description: fmt.Sprintf("Renombrar tabla %s a %s",
+			qualifiedTableName(oldTable.Schema, oldTable.TableName), qualifiedTableName(newTable.Schema, newTable.TableName)),
+	}
+
+	// Primero se mueve la tabla al nuevo schema y luego se renombra
+	schemaName := oldTable.Schema
+	if oldTable.Schema != newTable.Schema {
+		switch dialect {
+		case "sqlserver":
+			step.Statements = append(step.Statements, fmt.Sprintf("ALTER SCHEMA %s TRANSFER %s",
+				quoteIdentifier(newTable.Schema, dialect), quoteTableName(oldTable.Schema, oldTable.TableName, dialect)))
+			schemaName = newTable.Schema
+		case "postgres":
+			step.Statements = append(step.Statements, fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s",
+				quoteTableName(oldTable.Schema, oldTable.TableName, dialect), quoteIdentifier(newTable.Schema, dialect)))
+			schemaName = newTable.Schema
+		case "sybase":
+			step.Notes = append(step.Notes, "Sybase no permite cambiar el owner de una tabla; se debe recrear en el nuevo schema")
+		}
+	}
+
+	if oldTable.TableName == newTable.TableName {
+		return step
+	}
+
+	switch dialect {
+	case "sqlserver":
+		step.Statements = append(step.Statements, fmt.Sprintf("EXEC sp_rename %s, %s",
+			quoteString(qualifiedTableName(schemaName, oldTable.TableName)), quoteString(newTable.TableName)))
+	case "sybase":
+		step.Statements = append(step.Statements, fmt.Sprintf("exec sp_rename %s, %s",
+			quoteString(oldTable.TableName), quoteString(newTable.TableName)))
+	case "mysql":
+		step.Statements = append(step.Statements, fmt.Sprintf("RENAME TABLE %s TO %s",
+			quoteIdentifier(oldTable.TableName, dialect), quoteIdentifier(newTable.TableName, dialect)))
+	case "postgres":
+		step.Statements = append(step.Statements, fmt.Sprintf("ALTER TABLE %s RENAME TO %s",
+			quoteTableName(schemaName, oldTable.TableName, dialect), quoteIdentifier(newTable.TableName, dialect)))
+	}
+
+	return step
+}
+
+// Se elimina la clave primaria anterior con su nombre; MySQL no lo necesita
+// y PostgreSQL usa <tabla>_pkey cuando no se conoce
+func dropPrimaryKeyStep(oldTable, newTable Table, dialect string) (MigrationStep, bool) {
+	name, columns := primaryKeyDefinition(oldTable)
+	if len(columns) == 0 {
+		return MigrationStep{}, false
+	}
+
+	qualified := qualifiedTableName(newTable.Schema, newTable.TableName)
+	tableName := quoteTableName(newTable.Schema, newTable.TableName, dialect)
+	step := MigrationStep{
+		Table:       qualified,
+		Description: fmt.Sprintf("Eliminar clave primaria de %s (%s)", qualified, strings.Join(columns, ", ")),
+	}
+
+	if dialect == "postgres" && name == "" {
+		name = oldTable.TableName + "_pkey"
+	}
+
+	switch {
+	case dialect == "mysql":
+		step.Statements = []string{"ALTER TABLE " + tableName + " DROP PRIMARY KEY"}
+	case name == "":
+		step.Notes = []string{"se desconoce el nombre de la restricción; eliminarla manualmente"}
+	default:
+		step.Statements = []string{"ALTER TABLE " + tableName + " DROP CONSTRAINT " + quoteIdentifier(name, dialect)}
+	}
+
+	return step, true
+}
+
+func addColumnStep(table Table, col Column, dialect string) MigrationStep {
+	qualified := qualifiedTableName(table.Schema, table.TableName)
+	step := MigrationStep{
+		Table:       qualified,
+		Description: fmt.Sprintf("Agregar columna %s.%s", qualified, col.ColumnName),
+	}
+
+	keyword := " ADD COLUMN "
+	if dialect == "sqlserver" || dialect == "sybase" {
+		keyword = " ADD "
+	}
+	step.Statements = []string{"ALTER TABLE " + quoteTableName(table.Schema, table.TableName, dialect) +
+		keyword + generateColumnDefinition(col, dialect)}
+
+	if col.IsNullable == "NO" && col.DefaultValue == "" && !col.IsIdentity {
+		step.Notes = append(step.Notes, "columna NOT NULL sin valor por defecto: falla si la tabla tiene filas")
+	}
+
+	return step
+}
+
+func dropColumnStep(table Table, col Column, dialect string) MigrationStep {
+	qualified := qualifiedTableName(table.Schema, table.TableName)
+	step := MigrationStep{
+		Table:       qualified,
+		Description: fmt.Sprintf("Eliminar columna %s.%s", qualified, col.ColumnName),
+		Destructive: true,
+	}
+
+	keyword := " DROP COLUMN "
+	if dialect == "sybase" {
+		keyword = " DROP "
+	}
+	tableName := quoteTableName(table.Schema, table.TableName, dialect)
+
+	if dialect == "sqlserver" && col.DefaultValue != "" {
+		if col.DefaultConstraint != "" {
+			step.Statements = append(step.Statements, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", tableName, quoteIdentifier(col.DefaultConstraint, dialect)))
+		} else {
+			step.Notes = append(step.Notes, "eliminar antes la restricción DEFAULT de la columna")
+		}
+	}
+
+	step.Statements = append(step.Statements, "ALTER TABLE "+tableName+keyword+quoteIdentifier(col.ColumnName, dialect))
+
+	return step
+}
+
+// Genera las sentencias para los atributos que cambiaron. Devuelve false si
+// solo cambió la pertenencia a la clave primaria, que se trata aparte.
+func alterColumnStep(table Table, cd ColumnDiff, dialect string) (MigrationStep, bool) {
+	var typeChanged, nullChanged, defaultChanged, identityChanged bool
+	for _, c := range cd.Changes {
+		switch c.Attribute {
+		case "dataType", "maxLength", "precision", "scale", "datetimePrecision", "precisionUnspecified", "isUnsigned", "lengthSemantics":
+			typeChanged = true
+		case "isNullable":
+			nullChanged = true
+		case "defaultValue":
+			defaultChanged = true
+		case "isIdentity":
+			identityChanged = true
+		}
+	}
+	if !typeChanged && !nullChanged && !defaultChanged && !identityChanged {
+		return MigrationStep{}, false
+	}
+
+	oldCol, newCol := *cd.Old, *cd.New
+	qualified := qualifiedTableName(table.Schema, table.TableName)
+	tableName := quoteTableName(table.Schema, table.TableName, dialect)
+	column := quoteIdentifier(newCol.ColumnName, dialect)
+
+	var attributes []string
+	for _, c := range cd.Changes {
+		if c.Attribute != "isPrimaryKey" {
+			attributes = append(attributes, c.Attribute)
+		}
+	}
+
+	step := MigrationStep{
+		Table:       qualified,
+		Description: fmt.Sprintf("Modificar columna %s.%s (%s)", qualified, newCol.ColumnName, strings.Join(attributes, ", ")),
+		Destructive: typeChanged && isNarrowingChange(oldCol, newCol, dialect),
+	}
+
+	nullability := "NULL"
+	if newCol.IsNullable == "NO" || newCol.IsPrimaryKey {
+		nullability = "NOT NULL"
+	}
+	columnType := formatColumnType(newCol, dialect)
+	defaultValue := formatDefaultValue(newCol, dialect)
+
+	switch dialect {
+	case "mysql":
+		// MODIFY redefine la columna completa, incluido AUTO_INCREMENT
+		step.Statements = append(step.Statements, "ALTER TABLE "+tableName+" MODIFY COLUMN "+generateColumnDefinition(newCol, dialect))
+
+	case "sqlserver":
+		if typeChanged || nullChanged {
+			step.Statements = append(step.Statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s", tableName, column, columnType, nullability))
+		}
+		if defaultChanged {
+			if oldCol.DefaultConstraint != "" {
+				step.Statements = append(step.Statements, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", tableName, quoteIdentifier(oldCol.DefaultConstraint, dialect)))
+			} else if oldCol.DefaultValue != "" {
+				step.Notes = append(step.Notes, "eliminar antes la restricción DEFAULT anterior de la columna")
+			}
+			if defaultValue != "" {
+				constraint := ""
+				if newCol.DefaultConstraint != "" {
+					constraint = " CONSTRAINT " + quoteIdentifier(newCol.DefaultConstraint, dialect)
+				}
+				step.Statements = append(step.Statements, fmt.Sprintf("ALTER TABLE %s ADD%s DEFAULT %s FOR %s", tableName, constraint, defaultValue, column))
+			}
+		}
+		if identityChanged {
+			step.Notes = append(step.Notes, "SQL Server no permite agregar o quitar IDENTITY; se debe recrear la tabla")
+		}
+
+	case "sybase":
+		if typeChanged || nullChanged {
+			step.Statements = append(step.Statements, fmt.Sprintf("ALTER TABLE %s MODIFY %s %s %s", tableName, column, columnType, nullability))
+		}
+		if defaultChanged {
+			if defaultValue == "" {
+				defaultValue = "NULL"
+			}
+			step.Statements = append(step.Statements, fmt.Sprintf("ALTER TABLE %s REPLACE %s DEFAULT %s", tableName, column, defaultValue))
+		}
+		if identityChanged {
+			step.Notes = append(step.Notes, "Sybase no permite agregar o quitar IDENTITY; se debe recrear la tabla")
+		}
+
+	case "postgres":
+		alter := "ALTER TABLE " + tableName + " ALTER COLUMN " + column
+		if typeChanged {
+			step.Statements = append(step.Statements, fmt.Sprintf("%s TYPE %s USING %s::%s", alter, columnType, column, columnType))
+		}
+		if nullChanged {
+			if nullability == "NOT NULL" {
+				step.Statements = append(step.Statements, alter+" SET NOT NULL")
+			} else {
+				step.Statements = append(step.Statements, alter+" DROP NOT NULL")
+			}
+		}
+		if identityChanged {
+			if newCol.IsIdentity {
+				step.Statements = append(step.Statements, alter+" ADD GENERATED BY DEFAULT AS IDENTITY")
+			} else {
+				step.Statements = append(step.Statements, alter+" DROP IDENTITY IF EXISTS")
+			}
+		}
+		if defaultChanged {
+			if defaultValue != "" {
+				step.Statements = append(step.Statements, alter+" SET DEFAULT "+defaultValue)
+			} else if !newCol.IsIdentity {
+				step.Statements = append(step.Statements, alter+" DROP DEFAULT")
+			}
+		}
+	}
+
+	if nullChanged && nullability == "NOT NULL" && newCol.DefaultValue == "" {
+		step.Notes = append(step.Notes, "NOT NULL falla si existen filas con NULL en la columna")
+	}
+
+	return step, true
+}
+
+// Cadenas de tipos de cada motor, de menor a mayor capacidad: pasar a un tipo
+// posterior de la misma cadena no pierde datos
+var typeWidenings = map[string][][]string{
+	"sqlserver": {
+		{"tinyint", "smallint", "int", "bigint"},
+		{"real", "float"},
+		{"char", "varchar", "nvarchar"},
+		{"nchar", "nvarchar"},
+		{"char", "nchar"},
+		{"varchar", "text"},
+		{"nvarchar", "ntext"},
+		{"smalldatetime", "datetime", "datetime2"},
+		{"date", "datetime2"},
+	},
+	"sybase": {
+		{"tinyint", "smallint", "int", "bigint"},
+		{"real", "float"},
+		{"char", "varchar", "text"},
+		{"nchar", "nvarchar"},
+		{"unichar", "univarchar", "unitext"},
+		{"smalldatetime", "datetime", "bigdatetime"},
+		{"date", "datetime"},
+	},
+	"mysql": {
+		{"tinyint", "smallint", "mediumint", "int", "bigint"},
+		{"float", "double"},
+		{"char", "varchar", "mediumtext", "longtext"},
+		{"tinytext", "text", "mediumtext", "longtext"},
+		{"varchar", "text"},
+		{"binary", "varbinary", "mediumblob", "longblob"},
+		{"tinyblob", "blob", "mediumblob", "longblob"},
+		{"date", "datetime"},
+		{"timestamp", "datetime"},
+	},
+	"postgres": {
+		{"smallint", "integer", "bigint"},
+		{"real", "double precision"},
+		{"character", "character varying", "text"},
+		{"date", "timestamp without time zone"},
+	},
+	"oracle": {
+		{"binary_float", "binary_double"},
+		{"char", "varchar2", "clob"},
+		{"nchar", "nvarchar2", "nclob"},
+		{"date", "timestamp"},
+	},
+	"sqlite": {
+		{"smallint", "int", "integer", "bigint"},
+		{"real", "double"},
+		{"char", "varchar", "text"},
+	},
+}
+
+// Indica si el tipo nuevo es el mismo o uno más amplio que el anterior
+func isTypeWidening(oldType, newType, dialect string) bool {
+	oldType, newType = strings.ToLower(oldType), strings.ToLower(newType)
+	if oldType == newType {
+		return true
+	}
+
+	for _, chain := range typeWidenings[dialect] {
+		oldIndex, newIndex := -1, -1
+		for i, t := range chain {
+			switch t {
+			case oldType:
+				oldIndex = i
+			case newType:
+				newIndex = i
+			}
+		}
+		if oldIndex >= 0 && newIndex > oldIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// -1 (max) y 0 (sin longitud declarada, como text o varchar en PostgreSQL)
+// no tienen límite
+func lengthLimit(maxLength int) int {
+	if maxLength <= 0 {
+		return math.MaxInt32
+	}
+	return maxLength
+}
+
+// Precisión, escala y dígitos enteros de un tipo numérico. Sin precisión
+// (numeric de PostgreSQL, NUMBER de Oracle) no hay límite, salvo la escala
+// fija de NUMBER(*,s).
+func numericLimits(col Column) (int, int, int) {
+	if col.Precision > 0 {
+		return col.Precision, col.Scale, col.Precision - col.Scale
+	}
+	if col.PrecisionUnspecified {
+		return math.MaxInt32, col.Scale, math.MaxInt32
+	}
+	return math.MaxInt32, math.MaxInt32, math.MaxInt32
+}
+
+// Un cambio de tipo puede perder datos si el tipo nuevo no es el mismo ni
+// uno más amplio del mismo motor, o si se reduce la longitud, la precisión
+// o la escala
+func isNarrowingChange(oldCol, newCol Column, dialect string) bool {
+	if !isTypeWidening(oldCol.DataType, newCol.DataType, dialect) {
+		return true
+	}
+
+	// Un tipo sin signo solo admite los valores con signo de un tipo mayor
+	if newCol.IsUnsigned && !oldCol.IsUnsigned ||
+		oldCol.IsUnsigned && !newCol.IsUnsigned && strings.EqualFold(oldCol.DataType, newCol.DataType) {
+		return true
+	}
+
+	// Oracle: pasar de caracteres a bytes reduce la capacidad en multibyte
+	if oldCol.LengthSemantics == "CHAR" && newCol.LengthSemantics == "BYTE" {
+		return true
+	}
+
+	if lengthLimit(newCol.MaxLength) < lengthLimit(oldCol.MaxLength) {
+		return true
+	}
+
+	// Menos dígitos de fracción de segundo redondean los valores guardados
+	if oldCol.DatetimePrecision != nil && newCol.DatetimePrecision != nil &&
+		*newCol.DatetimePrecision < *oldCol.DatetimePrecision {
+		return true
+	}
+
+	oldPrecision, oldScale, oldDigits := numericLimits(oldCol)
+	newPrecision, newScale, newDigits := numericLimits(newCol)
+	return newPrecision < oldPrecision || newScale < oldScale || newDigits < oldDigits
+}
+
+// Escribe el script. Los pasos destructivos sin -allowdestructive quedan
+// comentados para que se puedan revisar y aplicar manualmente.
+func renderMigrationScript(diff *SchemaDiff, steps []MigrationStep, dialect string, allowDestructive bool) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "-- Migración generada para %s\n", dialect)
+	fmt.Fprintf(&sb, "-- Base:    %s\n", diff.Base)
+	fmt.Fprintf(&sb, "-- Destino: %s\n", diff.Target)
+	fmt.Fprintf(&sb, "-- Pasos: %d\n\n", len(steps))
+
+	if len(steps) == 0 {
+		sb.WriteString("-- Sin diferencias\n")
+		return sb.String()
+	}
+
+	if dialect == "sybase" {
+		sb.WriteString("set quoted_identifier on\ngo\n\n")
+	}
+
+	for i, step := range steps {
+		skip := step.Destructive && !allowDestructive
+
+		fmt.Fprintf(&sb, "-- %d. %s\n", i+1, step.Description)
+		if step.Destructive {
+			if skip {
+				sb.WriteString("-- DESTRUCTIVO: omitido, usar -allowdestructive para incluirlo\n")
+			} else {
+				sb.WriteString("-- DESTRUCTIVO: puede perder datos\n")
+			}
+		}
+		for _, note := range step.Notes {
+			sb.WriteString("-- Nota: " + note + "\n")
+		}
+
+		for _, stmt := range step.Statements {
+			if skip {
+				sb.WriteString("-- " + strings.ReplaceAll(stmt, "\n", "\n-- ") + "\n")
+				continue
+			}
+			sb.WriteString(stmt)
+			sb.WriteString(statementTerminator(dialect))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
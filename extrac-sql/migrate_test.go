@@ -0,0 +1,201 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIsNarrowingChange(t *testing.T) {
+	digits := func(value int) *int { return &value }
+
+	tests := []struct {
+		name    string
+		dialect string
+		old     Column
+		new     Column
+		want    bool
+	}{
+		{"integer a bigint", "postgres",
+			Column{DataType: "integer", Precision: 32}, Column{DataType: "bigint", Precision: 64}, false},
+		{"bigint a integer", "postgres",
+			Column{DataType: "bigint", Precision: 64}, Column{DataType: "integer", Precision: 32}, true},
+		{"varchar a text", "postgres",
+			Column{DataType: "character varying", MaxLength: 50}, Column{DataType: "text"}, false},
+		{"varchar sin longitud a varchar(10)", "postgres",
+			Column{DataType: "character varying"}, Column{DataType: "character varying", MaxLength: 10}, true},
+		{"varchar(50) a varchar(max)", "sqlserver",
+			Column{DataType: "varchar", MaxLength: 50}, Column{DataType: "varchar", MaxLength: -1}, false},
+		{"varchar(max) a varchar(50)", "sqlserver",
+			Column{DataType: "varchar", MaxLength: -1}, Column{DataType: "varchar", MaxLength: 50}, true},
+		{"datetime a datetime2", "sqlserver",
+			Column{DataType: "datetime", DatetimePrecision: digits(3)}, Column{DataType: "datetime2", DatetimePrecision: digits(7)}, false},
+		{"datetime2(7) a datetime2(0)", "sqlserver",
+			Column{DataType: "datetime2", DatetimePrecision: digits(7)}, Column{DataType: "datetime2", DatetimePrecision: digits(0)}, true},
+		{"timestamp(3) a timestamp(6)", "postgres",
+			Column{DataType: "timestamp without time zone", DatetimePrecision: digits(3)},
+			Column{DataType: "timestamp without time zone", DatetimePrecision: digits(6)}, false},
+		{"decimal con más precisión", "mysql",
+			Column{DataType: "decimal", Precision: 10, Scale: 2}, Column{DataType: "decimal", Precision: 12, Scale: 2}, false},
+		{"decimal con más escala y los mismos enteros", "mysql",
+			Column{DataType: "decimal", Precision: 10, Scale: 2}, Column{DataType: "decimal", Precision: 10, Scale: 4}, true},
+		{"numeric(10,2) a numeric", "postgres",
+			Column{DataType: "numeric", Precision: 10, Scale: 2}, Column{DataType: "numeric"}, false},
+		{"numeric a numeric(10,2)", "postgres",
+			Column{DataType: "numeric"}, Column{DataType: "numeric", Precision: 10, Scale: 2}, true},
+		{"NUMBER(10) a NUMBER", "oracle",
+			Column{DataType: "number", Precision: 10}, Column{DataType: "number"}, false},
+		{"NUMBER(*,0) a NUMBER", "oracle",
+			Column{DataType: "number", PrecisionUnspecified: true}, Column{DataType: "number"}, false},
+		{"NUMBER a NUMBER(*,0)", "oracle",
+			Column{DataType: "number"}, Column{DataType: "number", PrecisionUnspecified: true}, true},
+		{"int sin signo a bigint", "mysql",
+			Column{DataType: "int", Precision: 10, IsUnsigned: true}, Column{DataType: "bigint", Precision: 19}, false},
+		{"int a int sin signo", "mysql",
+			Column{DataType: "int", Precision: 10}, Column{DataType: "int", Precision: 10, IsUnsigned: true}, true},
+		{"int a varchar", "mysql",
+			Column{DataType: "int", Precision: 10}, Column{DataType: "varchar", MaxLength: 20}, true},
+		{"cadena de otro motor", "mysql",
+			Column{DataType: "smalldatetime"}, Column{DataType: "datetime"}, true},
+		{"CHAR a BYTE", "oracle",
+			Column{DataType: "varchar2", MaxLength: 10, LengthSemantics: "CHAR"}, Column{DataType: "varchar2", MaxLength: 10, LengthSemantics: "BYTE"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNarrowingChange(tt.old, tt.new, tt.dialect); got != tt.want {
+				t.Errorf("isNarrowingChange() = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateMigrationSteps(t *testing.T) {
+	clientes := Table{TableName: "clientes", Schema: "public", Columns: []Column{
+		{ColumnName: "id", DataType: "integer", Precision: 32, IsNullable: "NO", IsPrimaryKey: true},
+		{ColumnName: "nombre", DataType: "character varying", MaxLength: 50, IsNullable: "YES"},
+		{ColumnName: "fax", DataType: "character varying", MaxLength: 20, IsNullable: "YES"},
+	}}
+
+	withColumns := func(columns ...Column) Table {
+		table := clientes
+		table.Columns = columns
+		return table
+	}
+	id, nombre, fax := clientes.Columns[0], clientes.Columns[1], clientes.Columns[2]
+
+	bigintID := id
+	bigintID.DataType, bigintID.Precision = "bigint", 64
+	longNombre := nombre
+	longNombre.MaxLength = 100
+	shortNombre := nombre
+	shortNombre.MaxLength = 20
+	email := Column{ColumnName: "email", DataType: "text", IsNullable: "YES"}
+
+	tests := []struct {
+		name            string
+		base            []Table
+		target          []Table
+		wantSteps       []string
+		wantDestructive []bool
+	}{
+		{
+			name:            "ampliar la longitud no es destructivo",
+			base:            []Table{clientes},
+			target:          []Table{withColumns(id, longNombre, fax)},
+			wantSteps:       []string{"Modificar columna public.clientes.nombre (maxLength)"},
+			wantDestructive: []bool{false},
+		},
+		{
+			name:            "ampliar a bigint no es destructivo",
+			base:            []Table{clientes},
+			target:          []Table{withColumns(bigintID, nombre, fax)},
+			wantSteps:       []string{"Modificar columna public.clientes.id (dataType, precision)"},
+			wantDestructive: []bool{false},
+		},
+		{
+			name:            "reducir la longitud es destructivo",
+			base:            []Table{clientes},
+			target:          []Table{withColumns(id, shortNombre, fax)},
+			wantSteps:       []string{"Modificar columna public.clientes.nombre (maxLength)"},
+			wantDestructive: []bool{true},
+		},
+		{
+			name:   "crear, agregar y eliminar en orden",
+			base:   []Table{clientes},
+			target: []Table{withColumns(id, nombre, email), {TableName: "pedidos", Schema: "public", Columns: []Column{id}}},
+			wantSteps: []string{
+				"Crear tabla public.pedidos",
+				"Agregar columna public.clientes.email",
+				"Eliminar columna public.clientes.fax",
+			},
+			wantDestructive: []bool{false, false, true},
+		},
+		{
+			name:            "renombrar antes que el resto",
+			base:            []Table{clientes},
+			target:          []Table{{TableName: "customers", Schema: "public", Columns: clientes.Columns}},
+			wantSteps:       []string{"Renombrar tabla public.clientes a public.customers"},
+			wantDestructive: []bool{false},
+		},
+		{
+			name:            "eliminar tabla",
+			base:            []Table{clientes},
+			target:          []Table{},
+			wantSteps:       []string{"Eliminar tabla public.clientes"},
+			wantDestructive: []bool{true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := diffDatabaseSchemas(
+				&DatabaseSchema{DBType: "postgres", Tables: tt.base},
+				&DatabaseSchema{DBType: "postgres", Tables: tt.target},
+			)
+			steps := generateMigrationSteps(diff, "postgres")
+
+			var descriptions []string
+			var destructive []bool
+			for _, step := range steps {
+				descriptions = append(descriptions, step.Description)
+				destructive = append(destructive, step.Destructive)
+				if len(step.Statements) == 0 {
+					t.Errorf("%s: paso sin sentencias", step.Description)
+				}
+			}
+
+			if !reflect.DeepEqual(descriptions, tt.wantSteps) {
+				t.Fatalf("pasos = %q, se esperaba %q", descriptions, tt.wantSteps)
+			}
+			if !reflect.DeepEqual(destructive, tt.wantDestructive) {
+				t.Errorf("destructivos = %v, se esperaba %v", destructive, tt.wantDestructive)
+			}
+		})
+	}
+}
+
+func TestGenerateMigrationStepsTypeStatement(t *testing.T) {
+	base := Table{TableName: "pedidos", Schema: "public", Columns: []Column{{ColumnName: "total", DataType: "integer", Precision: 32, IsNullable: "YES"}}}
+	target := base
+	target.Columns = []Column{{ColumnName: "total", DataType: "bigint", Precision: 64, IsNullable: "YES"}}
+
+	diff := diffDatabaseSchemas(
+		&DatabaseSchema{DBType: "postgres", Tables: []Table{base}},
+		&DatabaseSchema{DBType: "postgres", Tables: []Table{target}},
+	)
+	steps := generateMigrationSteps(diff, "postgres")
+	if len(steps) != 1 || len(steps[0].Statements) != 1 {
+		t.Fatalf("pasos = %+v, se esperaba un ALTER", steps)
+	}
+
+	statement := steps[0].Statements[0]
+	if !strings.Contains(statement, "ALTER COLUMN") || !strings.Contains(statement, "TYPE bigint") {
+		t.Errorf("sentencia = %q, se esperaba ALTER COLUMN ... TYPE bigint", statement)
+	}
+
+	script := renderMigrationScript(diff, steps, "postgres", false)
+	if strings.Contains(script, "DESTRUCTIVO") {
+		t.Errorf("el script marca como destructiva una ampliación de tipo:\n%s", script)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Opciones de una colección de series temporales
+type MongoTimeseriesOptions struct {
+	TimeField             string `json:"timeField" bson:"timeField"`
+	MetaField             string `json:"metaField,omitempty" bson:"metaField"`
+	Granularity           string `json:"granularity,omitempty" bson:"granularity"`
+	BucketMaxSpanSeconds  int64  `json:"bucketMaxSpanSeconds,omitempty" bson:"bucketMaxSpanSeconds"`
+	BucketRoundingSeconds int64  `json:"bucketRoundingSeconds,omitempty" bson:"bucketRoundingSeconds"`
+}
+
+// Índice agrupado definido al crear la colección (MongoDB 5.3+)
+type MongoClusteredIndex struct {
+	Name   string          `json:"name,omitempty"`
+	Keys   []MongoIndexKey `json:"keys"`
+	Unique bool            `json:"unique"`
+}
+
+// Especificación de colección tal como la devuelve listCollections
+type mongoCollectionSpec struct {
+	Name    string `bson:"name"`
+	Type    string `bson:"type"`
+	Options struct {
+		Capped             bool                     `bson:"capped"`
+		Size               int64                    `bson:"size"`
+		Max                int64                    `bson:"max"`
+		ViewOn             string                   `bson:"viewOn"`
+		Pipeline           []map[string]interface{} `bson:"pipeline"`
+		Validator          map[string]interface{}   `bson:"validator"`
+		ValidationLevel    string                   `bson:"validationLevel"`
+		ValidationAction   string                   `bson:"validationAction"`
+		Timeseries         *MongoTimeseriesOptions  `bson:"timeseries"`
+		ExpireAfterSeconds *int64                   `bson:"expireAfterSeconds"`
+		ClusteredIndex     *struct {
+			Name   string `bson:"name"`
+			Key    bson.D `bson:"key"`
+			Unique bool   `bson:"unique"`
+		} `bson:"clusteredIndex"`
+	} `bson:"options"`
+}
+
+// Obtiene las especificaciones completas de todas las colecciones de la base
+func listMongoCollectionSpecs(db *mongo.Database) ([]mongoCollectionSpec, error) {
+	cursor, err := db.ListCollections(nil, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("error al listar colecciones: %v", err)
+	}
+	defer cursor.Close(nil)
+
+	var specs []mongoCollectionSpec
+
+	for cursor.Next(nil) {
+		var spec mongoCollectionSpec
+		if err := cursor.Decode(&spec); err != nil {
+			return nil, fmt.Errorf("error al decodificar colección: %v", err)
+		}
+		specs = append(specs, spec)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre colecciones: %v", err)
+	}
+
+	return specs, nil
+}
+
+// Crea la colección de salida con el tipo y las opciones de la especificación
+func newMongoCollection(databaseName string, spec mongoCollectionSpec) MongoCollection {
+	collection := MongoCollection{
+		CollectionName:     spec.Name,
+		DatabaseName:       databaseName,
+		CollectionType:     spec.Type,
+		Indexes:            []MongoIndex{},
+		ViewOn:             spec.Options.ViewOn,
+		Pipeline:           spec.Options.Pipeline,
+		Capped:             spec.Options.Capped,
+		Validator:          spec.Options.Validator,
+		ValidationLevel:    spec.Options.ValidationLevel,
+		ValidationAction:   spec.Options.ValidationAction,
+		Timeseries:         spec.Options.Timeseries,
+		ExpireAfterSeconds: spec.Options.ExpireAfterSeconds,
+	}
+
+	// Servidores anteriores a 3.4 no informan el tipo
+	if collection.CollectionType == "" {
+		collection.CollectionType = "collection"
+	}
+
+	if spec.Options.Capped {
+		collection.CappedSize = spec.Options.Size
+		collection.CappedMax = spec.Options.Max
+	}
+
+	if ci := spec.Options.ClusteredIndex; ci != nil {
+		clustered := &MongoClusteredIndex{
+			Name:   ci.Name,
+			Keys:   []MongoIndexKey{},
+			Unique: ci.Unique,
+		}
+		for _, elem := range ci.Key {
+			clustered.Keys = append(clustered.Keys, parseMongoIndexKey(elem.Key, elem.Value))
+		}
+		collection.ClusteredIndex = clustered
+	}
+
+	return collection
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Decodifica documentos como los que devuelve listCollections
+func TestNewMongoCollection(t *testing.T) {
+	decode := func(doc bson.D) mongoCollectionSpec {
+		t.Helper()
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			t.Fatalf("bson.Marshal: %v", err)
+		}
+		var spec mongoCollectionSpec
+		if err := bson.Unmarshal(raw, &spec); err != nil {
+			t.Fatalf("bson.Unmarshal: %v", err)
+		}
+		return spec
+	}
+
+	ttl := int64(86400)
+	validator := map[string]interface{}{"$jsonSchema": map[string]interface{}{"bsonType": "object"}}
+
+	tests := []struct {
+		name string
+		doc  bson.D
+		want MongoCollection
+	}{
+		{
+			name: "sin tipo informado",
+			doc:  bson.D{{Key: "name", Value: "clientes"}, {Key: "options", Value: bson.D{}}},
+			want: MongoCollection{CollectionName: "clientes", DatabaseName: "ventas", CollectionType: "collection", Indexes: []MongoIndex{}},
+		},
+		{
+			name: "con validador",
+			doc: bson.D{{Key: "name", Value: "pedidos"}, {Key: "type", Value: "collection"}, {Key: "options", Value: bson.D{
+				{Key: "validator", Value: validator}, {Key: "validationLevel", Value: "moderate"}, {Key: "validationAction", Value: "warn"}}}},
+			want: MongoCollection{CollectionName: "pedidos", DatabaseName: "ventas", CollectionType: "collection", Indexes: []MongoIndex{},
+				Validator: validator, ValidationLevel: "moderate", ValidationAction: "warn"},
+		},
+		{
+			name: "capped",
+			doc: bson.D{{Key: "name", Value: "log"}, {Key: "type", Value: "collection"}, {Key: "options", Value: bson.D{
+				{Key: "capped", Value: true}, {Key: "size", Value: int64(1048576)}, {Key: "max", Value: int64(1000)}}}},
+			want: MongoCollection{CollectionName: "log", DatabaseName: "ventas", CollectionType: "collection", Indexes: []MongoIndex{},
+				Capped: true, CappedSize: 1048576, CappedMax: 1000},
+		},
+		{
+			name: "vista",
+			doc: bson.D{{Key: "name", Value: "v_activos"}, {Key: "type", Value: "view"}, {Key: "options", Value: bson.D{
+				{Key: "viewOn", Value: "clientes"}, {Key: "pipeline", Value: bson.A{bson.D{{Key: "$match", Value: bson.D{{Key: "activo", Value: true}}}}}}}}},
+			want: MongoCollection{CollectionName: "v_activos", DatabaseName: "ventas", CollectionType: "view", Indexes: []MongoIndex{},
+				ViewOn: "clientes", Pipeline: []map[string]interface{}{{"$match": map[string]interface{}{"activo": true}}}},
+		},
+		{
+			name: "series temporales",
+			doc: bson.D{{Key: "name", Value: "lecturas"}, {Key: "type", Value: "timeseries"}, {Key: "options", Value: bson.D{
+				{Key: "timeseries", Value: bson.D{{Key: "timeField", Value: "ts"}, {Key: "metaField", Value: "sensor"}, {Key: "granularity", Value: "minutes"}}},
+				{Key: "expireAfterSeconds", Value: ttl}}}},
+			want: MongoCollection{CollectionName: "lecturas", DatabaseName: "ventas", CollectionType: "timeseries", Indexes: []MongoIndex{},
+				Timeseries: &MongoTimeseriesOptions{TimeField: "ts", MetaField: "sensor", Granularity: "minutes"}, ExpireAfterSeconds: &ttl},
+		},
+		{
+			name: "índice agrupado",
+			doc: bson.D{{Key: "name", Value: "eventos"}, {Key: "type", Value: "collection"}, {Key: "options", Value: bson.D{
+				{Key: "clusteredIndex", Value: bson.D{{Key: "key", Value: bson.D{{Key: "_id", Value: 1}}}, {Key: "unique", Value: true}, {Key: "name", Value: "por_id"}}}}}},
+			want: MongoCollection{CollectionName: "eventos", DatabaseName: "ventas", CollectionType: "collection", Indexes: []MongoIndex{},
+				ClusteredIndex: &MongoClusteredIndex{Name: "por_id", Keys: []MongoIndexKey{{Field: "_id", Direction: 1}}, Unique: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newMongoCollection("ventas", decode(tt.doc)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("colección = %+v\nse esperaba %+v", got, tt.want)
+			}
+		})
+	}
+}
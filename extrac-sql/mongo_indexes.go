@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Especificación de índice tal como la devuelve listIndexes
+type mongoIndexSpec struct {
+	Name                    string                 `bson:"name"`
+	Key                     bson.D                 `bson:"key"`
+	Unique                  bool                   `bson:"unique"`
+	Sparse                  bool                   `bson:"sparse"`
+	Hidden                  bool                   `bson:"hidden"`
+	ExpireAfterSeconds      *int64                 `bson:"expireAfterSeconds"`
+	PartialFilterExpression map[string]interface{} `bson:"partialFilterExpression"`
+	Collation               map[string]interface{} `bson:"collation"`
+	WildcardProjection      map[string]interface{} `bson:"wildcardProjection"`
+	Weights                 map[string]interface{} `bson:"weights"`
+	DefaultLanguage         string                 `bson:"default_language"`
+}
+
+func extractMongoIndexes(coll *mongo.Collection) ([]MongoIndex, error) {
+	cursor, err := coll.Indexes().List(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error al listar índices: %v", err)
+	}
+	defer cursor.Close(nil)
+
+	indexes := []MongoIndex{}
+
+	for cursor.Next(nil) {
+		var spec mongoIndexSpec
+		if err := cursor.Decode(&spec); err != nil {
+			return nil, fmt.Errorf("error al decodificar índice: %v", err)
+		}
+
+		indexes = append(indexes, newMongoIndex(spec))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre índices: %v", err)
+	}
+
+	return indexes, nil
+}
+
+// Convierte la especificación de listIndexes en un MongoIndex con las claves
+// en orden
+func newMongoIndex(spec mongoIndexSpec) MongoIndex {
+	index := MongoIndex{
+		Name:                    spec.Name,
+		Keys:                    []MongoIndexKey{},
+		Unique:                  spec.Unique,
+		Sparse:                  spec.Sparse,
+		Hidden:                  spec.Hidden,
+		ExpireAfterSeconds:      spec.ExpireAfterSeconds,
+		PartialFilterExpression: spec.PartialFilterExpression,
+		Collation:               spec.Collation,
+		WildcardProjection:      spec.WildcardProjection,
+		Weights:                 spec.Weights,
+		DefaultLanguage:         spec.DefaultLanguage,
+	}
+
+	for _, elem := range spec.Key {
+		// Los índices de texto se almacenan como {_fts: "text", _ftsx: 1};
+		// los campos reales están en weights
+		if elem.Key == "_fts" {
+			fields := make([]string, 0, len(spec.Weights))
+			for field := range spec.Weights {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			for _, field := range fields {
+				index.Keys = append(index.Keys, MongoIndexKey{Field: field, Type: "text"})
+			}
+			continue
+		}
+		if elem.Key == "_ftsx" {
+			continue
+		}
+
+		key := parseMongoIndexKey(elem.Key, elem.Value)
+		if strings.HasSuffix(key.Field, "$**") {
+			index.IsWildcard = true
+		}
+		index.Keys = append(index.Keys, key)
+	}
+
+	return index
+}
+
+// Convierte el valor de una clave de índice: los números indican la
+// dirección y los textos el tipo de índice especial (2d, 2dsphere, hashed...)
+func parseMongoIndexKey(field string, value interface{}) MongoIndexKey {
+	key := MongoIndexKey{Field: field}
+
+	switch v := value.(type) {
+	case int32:
+		key.Direction = int(v)
+	case int64:
+		key.Direction = int(v)
+	case float64:
+		key.Direction = int(v)
+	case string:
+		key.Type = v
+	default:
+		key.Type = fmt.Sprintf("%v", v)
+	}
+
+	return key
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParseMongoIndexKey(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  MongoIndexKey
+	}{
+		{int32(1), MongoIndexKey{Field: "f", Direction: 1}},
+		{int64(-1), MongoIndexKey{Field: "f", Direction: -1}},
+		{float64(1), MongoIndexKey{Field: "f", Direction: 1}},
+		{"2dsphere", MongoIndexKey{Field: "f", Type: "2dsphere"}},
+		{"hashed", MongoIndexKey{Field: "f", Type: "hashed"}},
+	}
+
+	for _, tt := range tests {
+		if got := parseMongoIndexKey("f", tt.value); got != tt.want {
+			t.Errorf("parseMongoIndexKey(%v) = %+v, se esperaba %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+// Decodifica documentos como los que devuelve listIndexes
+func TestNewMongoIndex(t *testing.T) {
+	decode := func(doc bson.D) mongoIndexSpec {
+		t.Helper()
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			t.Fatalf("bson.Marshal: %v", err)
+		}
+		var spec mongoIndexSpec
+		if err := bson.Unmarshal(raw, &spec); err != nil {
+			t.Fatalf("bson.Unmarshal: %v", err)
+		}
+		return spec
+	}
+
+	ttl := int64(3600)
+	tests := []struct {
+		name string
+		doc  bson.D
+		want MongoIndex
+	}{
+		{
+			name: "compuesto con TTL",
+			doc: bson.D{{Key: "v", Value: 2}, {Key: "key", Value: bson.D{{Key: "cliente", Value: 1}, {Key: "fecha", Value: -1}}},
+				{Key: "name", Value: "cliente_1_fecha_-1"}, {Key: "unique", Value: true}, {Key: "expireAfterSeconds", Value: ttl}},
+			want: MongoIndex{Name: "cliente_1_fecha_-1", Unique: true, ExpireAfterSeconds: &ttl,
+				Keys: []MongoIndexKey{{Field: "cliente", Direction: 1}, {Field: "fecha", Direction: -1}}},
+		},
+		{
+			name: "texto con los campos de weights",
+			doc: bson.D{{Key: "key", Value: bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: 1}}},
+				{Key: "name", Value: "texto"}, {Key: "weights", Value: bson.D{{Key: "titulo", Value: 10}, {Key: "cuerpo", Value: 1}}},
+				{Key: "default_language", Value: "spanish"}},
+			want: MongoIndex{Name: "texto", DefaultLanguage: "spanish",
+				Weights: map[string]interface{}{"titulo": int32(10), "cuerpo": int32(1)},
+				Keys:    []MongoIndexKey{{Field: "cuerpo", Type: "text"}, {Field: "titulo", Type: "text"}}},
+		},
+		{
+			name: "comodín",
+			doc:  bson.D{{Key: "key", Value: bson.D{{Key: "atributos.$**", Value: 1}}}, {Key: "name", Value: "atributos.$**_1"}},
+			want: MongoIndex{Name: "atributos.$**_1", IsWildcard: true,
+				Keys: []MongoIndexKey{{Field: "atributos.$**", Direction: 1}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newMongoIndex(decode(tt.doc)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("índice = %+v, se esperaba %+v", got, tt.want)
+			}
+		})
+	}
+}
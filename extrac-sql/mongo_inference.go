@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Esquema inferido a partir de una muestra de documentos de la colección
+type InferredSchema struct {
+	SampleSize    int             `json:"sampleSize"`
+	DocumentCount int             `json:"documentCount"`
+	Fields        []InferredField `json:"fields"`
+}
+
+// Campo inferido. PresenceRatio se calcula sobre los documentos que
+// contienen al campo: el total de la muestra para los campos de primer
+// nivel, o las veces que el campo padre fue un documento para los anidados.
+type InferredField struct {
+	Name              string          `json:"name"`
+	Path              string          `json:"path"`
+	Count             int             `json:"count"`
+	PresenceRatio     float64         `json:"presenceRatio"`
+	Types             []ObservedType  `json:"types"`
+	IsPolymorphic     bool            `json:"isPolymorphic"`
+	ArrayElementTypes []ObservedType  `json:"arrayElementTypes,omitempty"`
+	StringValues      []string        `json:"stringValues,omitempty"`
+	Fields            []InferredField `json:"fields,omitempty"`
+}
+
+// Tipo BSON observado y número de veces que apareció
+type ObservedType struct {
+	BSONType string `json:"bsonType"`
+	Count    int    `json:"count"`
+}
+
+// Máximo de valores de texto distintos que se registran por campo; por
+// encima de este número el campo no se considera de baja cardinalidad
+const maxTrackedStringValues = 50
+
+// Acumulador interno de estadísticas por ruta durante el recorrido
+type fieldStats struct {
+	name         string
+	path         string
+	count        int
+	types        map[string]int
+	arrayTypes   map[string]int
+	objectCount  int
+	children     map[string]*fieldStats
+	stringCount  int
+	stringValues map[string]int
+}
+
+func newFieldStats(name, path string) *fieldStats {
+	return &fieldStats{
+		name:         name,
+		path:         path,
+		types:        make(map[string]int),
+		arrayTypes:   make(map[string]int),
+		children:     make(map[string]*fieldStats),
+		stringValues: make(map[string]int),
+	}
+}
+
+// Muestrea documentos con $sample y construye el árbol de campos inferido.
+// También devuelve el primer documento de la muestra como documento de ejemplo.
+func inferMongoCollectionSchema(coll *mongo.Collection, sampleSize int) (*InferredSchema, map[string]interface{}, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$sample", Value: bson.D{{Key: "size", Value: sampleSize}}}},
+	}
+
+	cursor, err := coll.Aggregate(nil, pipeline)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al muestrear documentos: %v", err)
+	}
+	defer cursor.Close(nil)
+
+	root := newFieldStats("", "")
+	var sampleDocument map[string]interface{}
+
+	for cursor.Next(nil) {
+		if sampleDocument == nil {
+			if err := cursor.Decode(&sampleDocument); err != nil {
+				return nil, nil, fmt.Errorf("error al decodificar documento de muestra: %v", err)
+			}
+		}
+
+		root.objectCount++
+		if err := root.observeDocument(cursor.Current); err != nil {
+			return nil, nil, fmt.Errorf("error al analizar documento: %v", err)
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterando sobre documentos: %v", err)
+	}
+
+	schema := &InferredSchema{
+		SampleSize:    sampleSize,
+		DocumentCount: root.objectCount,
+		Fields:        root.buildChildren(),
+	}
+
+	return schema, sampleDocument, nil
+}
+
+// Registra cada campo del documento como hijo del nodo actual
+func (s *fieldStats) observeDocument(doc bson.Raw) error {
+	elements, err := doc.Elements()
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range elements {
+		key := elem.Key()
+		child, ok := s.children[key]
+		if !ok {
+			path := key
+			if s.path != "" {
+				path = s.path + "." + key
+			}
+			child = newFieldStats(key, path)
+			s.children[key] = child
+		}
+
+		child.count++
+		if err := child.observeValue(elem.Value(), false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Registra el tipo de un valor y recorre documentos y arreglos anidados.
+// Los documentos dentro de arreglos se agregan como hijos del mismo nodo,
+// igual que la notación de puntos de MongoDB ("items.sku").
+func (s *fieldStats) observeValue(value bson.RawValue, inArray bool) error {
+	if inArray {
+		s.arrayTypes[bsonTypeAlias(value.Type)]++
+	} else {
+		s.types[bsonTypeAlias(value.Type)]++
+	}
+
+	switch value.Type {
+	case bsontype.String:
+		if !inArray {
+			s.observeString(value.StringValue())
+		}
+	case bsontype.EmbeddedDocument:
+		s.objectCount++
+		return s.observeDocument(value.Document())
+	case bsontype.Array:
+		values, err := value.Array().Values()
+		if err != nil {
+			return err
+		}
+		for _, v := range values {
+			if err := s.observeValue(v, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Cuenta los valores de texto distintos hasta maxTrackedStringValues; al
+// superarlo se descartan para no retener valores de campos libres
+func (s *fieldStats) observeString(value string) {
+	s.stringCount++
+	if s.stringValues == nil {
+		return
+	}
+
+	s.stringValues[value]++
+	if len(s.stringValues) > maxTrackedStringValues {
+		s.stringValues = nil
+	}
+}
+
+// Devuelve los valores de texto observados si el campo es de baja
+// cardinalidad: cada valor debe repetirse en promedio al menos dos veces,
+// lo que evita listar identificadores o textos libres de muestras pequeñas
+func (s *fieldStats) lowCardinalityStrings() []string {
+	if len(s.stringValues) == 0 || s.stringCount < 2*len(s.stringValues) {
+		return nil
+	}
+
+	values := make([]string, 0, len(s.stringValues))
+	for v := range s.stringValues {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+
+	return values
+}
+
+// Convierte los acumuladores hijos en campos inferidos ordenados por nombre
+func (s *fieldStats) buildChildren() []InferredField {
+	names := make([]string, 0, len(s.children))
+	for name := range s.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]InferredField, 0, len(names))
+	for _, name := range names {
+		child := s.children[name]
+
+		field := InferredField{
+			Name:              child.name,
+			Path:              child.path,
+			Count:             child.count,
+			Types:             sortObservedTypes(child.types),
+			ArrayElementTypes: sortObservedTypes(child.arrayTypes),
+			StringValues:      child.lowCardinalityStrings(),
+			Fields:            child.buildChildren(),
+		}
+		if s.objectCount > 0 {
+			field.PresenceRatio = float64(child.count) / float64(s.objectCount)
+		}
+		field.IsPolymorphic = countNonNullTypes(child.types) > 1
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// Ordena los tipos observados de más a menos frecuente
+func sortObservedTypes(types map[string]int) []ObservedType {
+	if len(types) == 0 {
+		return nil
+	}
+
+	result := make([]ObservedType, 0, len(types))
+	for t, count := range types {
+		result = append(result, ObservedType{BSONType: t, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].BSONType < result[j].BSONType
+	})
+
+	return result
+}
+
+func countNonNullTypes(types map[string]int) int {
+	n := 0
+	for t := range types {
+		if t != "null" && t != "undefined" {
+			n++
+		}
+	}
+	return n
+}
+
+// Devuelve el alias del tipo BSON usado por $type y $jsonSchema
+func bsonTypeAlias(t bsontype.Type) string {
+	switch t {
+	case bsontype.Double:
+		return "double"
+	case bsontype.String:
+		return "string"
+	case bsontype.EmbeddedDocument:
+		return "object"
+	case bsontype.Array:
+		return "array"
+	case bsontype.Binary:
+		return "binData"
+	case bsontype.Undefined:
+		return "undefined"
+	case bsontype.ObjectID:
+		return "objectId"
+	case bsontype.Boolean:
+		return "bool"
+	case bsontype.DateTime:
+		return "date"
+	case bsontype.Null:
+		return "null"
+	case bsontype.Regex:
+		return "regex"
+	case bsontype.DBPointer:
+		return "dbPointer"
+	case bsontype.JavaScript:
+		return "javascript"
+	case bsontype.Symbol:
+		return "symbol"
+	case bsontype.CodeWithScope:
+		return "javascriptWithScope"
+	case bsontype.Int32:
+		return "int"
+	case bsontype.Timestamp:
+		return "timestamp"
+	case bsontype.Int64:
+		return "long"
+	case bsontype.Decimal128:
+		return "decimal"
+	case bsontype.MinKey:
+		return "minKey"
+	case bsontype.MaxKey:
+		return "maxKey"
+	default:
+		return t.String()
+	}
+}
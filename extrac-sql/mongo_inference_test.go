@@ -0,0 +1,98 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Recorre los documentos como lo hace inferMongoCollectionSchema con la
+// muestra de $sample
+func inferTestFields(t *testing.T, docs ...bson.D) []InferredField {
+	t.Helper()
+
+	root := newFieldStats("", "")
+	for _, doc := range docs {
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			t.Fatalf("bson.Marshal: %v", err)
+		}
+		root.objectCount++
+		if err := root.observeDocument(raw); err != nil {
+			t.Fatalf("observeDocument: %v", err)
+		}
+	}
+	return root.buildChildren()
+}
+
+func findInferredField(fields []InferredField, path string) *InferredField {
+	for i := range fields {
+		if fields[i].Path == path {
+			return &fields[i]
+		}
+		if found := findInferredField(fields[i].Fields, path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func TestInferFields(t *testing.T) {
+	fields := inferTestFields(t,
+		bson.D{{Key: "nombre", Value: "Ana"}, {Key: "edad", Value: int32(30)},
+			{Key: "direccion", Value: bson.D{{Key: "ciudad", Value: "Lima"}, {Key: "cp", Value: "15001"}}},
+			{Key: "items", Value: bson.A{bson.D{{Key: "sku", Value: "A1"}}, bson.D{{Key: "sku", Value: "B2"}, {Key: "cantidad", Value: int32(2)}}}}},
+		bson.D{{Key: "nombre", Value: "Luis"}, {Key: "edad", Value: "treinta"}, {Key: "direccion", Value: bson.D{{Key: "ciudad", Value: "Quito"}}}},
+		bson.D{{Key: "nombre", Value: "Eva"}, {Key: "edad", Value: nil}, {Key: "items", Value: bson.A{}}},
+		bson.D{{Key: "nombre", Value: "Sol"}},
+	)
+
+	var names []string
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+	if want := []string{"direccion", "edad", "items", "nombre"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("campos = %v, se esperaba %v", names, want)
+	}
+
+	tests := []struct {
+		path        string
+		count       int
+		ratio       float64
+		types       []ObservedType
+		polymorphic bool
+	}{
+		{"nombre", 4, 1, []ObservedType{{"string", 4}}, false},
+		{"edad", 3, 0.75, []ObservedType{{"int", 1}, {"null", 1}, {"string", 1}}, true},
+		{"direccion", 2, 0.5, []ObservedType{{"object", 2}}, false},
+		// Los anidados se miden sobre las veces que el padre fue un documento
+		{"direccion.ciudad", 2, 1, []ObservedType{{"string", 2}}, false},
+		{"direccion.cp", 1, 0.5, []ObservedType{{"string", 1}}, false},
+		{"items", 2, 0.5, []ObservedType{{"array", 2}}, false},
+		{"items.sku", 2, 1, []ObservedType{{"string", 2}}, false},
+		{"items.cantidad", 1, 0.5, []ObservedType{{"int", 1}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			field := findInferredField(fields, tt.path)
+			if field == nil {
+				t.Fatalf("no se infirió el campo %s", tt.path)
+			}
+			if field.Count != tt.count || field.PresenceRatio != tt.ratio {
+				t.Errorf("count/presenceRatio = %d/%v, se esperaba %d/%v", field.Count, field.PresenceRatio, tt.count, tt.ratio)
+			}
+			if !reflect.DeepEqual(field.Types, tt.types) {
+				t.Errorf("tipos = %v, se esperaba %v", field.Types, tt.types)
+			}
+			if field.IsPolymorphic != tt.polymorphic {
+				t.Errorf("isPolymorphic = %v, se esperaba %v", field.IsPolymorphic, tt.polymorphic)
+			}
+		})
+	}
+
+	if items := findInferredField(fields, "items"); !reflect.DeepEqual(items.ArrayElementTypes, []ObservedType{{"object", 2}}) {
+		t.Errorf("tipos de los elementos de items = %v", items.ArrayElementTypes)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+// Umbrales usados para generar el validador $jsonSchema
+type ValidatorOptions struct {
+	// Proporción mínima de presencia para marcar un campo como requerido
+	RequiredRatio float64
+	// Máximo de valores distintos para generar un enum en campos de texto
+	EnumMaxValues int
+}
+
+// Genera un validador {"$jsonSchema": {...}} listo para aplicar con
+// collMod o createCollection a partir del esquema inferido
+func buildMongoValidator(schema *InferredSchema, opts ValidatorOptions) map[string]interface{} {
+	return map[string]interface{}{
+		"$jsonSchema": buildJSONSchemaObject(schema.Fields, opts),
+	}
+}
+
+// Construye el nodo de tipo object con sus propiedades y campos requeridos
+func buildJSONSchemaObject(fields []InferredField, opts ValidatorOptions) map[string]interface{} {
+	node := map[string]interface{}{
+		"bsonType": "object",
+	}
+
+	properties, required := buildJSONSchemaProperties(fields, opts)
+	if len(properties) > 0 {
+		node["properties"] = properties
+	}
+	if len(required) > 0 {
+		node["required"] = required
+	}
+
+	return node
+}
+
+func buildJSONSchemaProperties(fields []InferredField, opts ValidatorOptions) (map[string]interface{}, []string) {
+	properties := make(map[string]interface{}, len(fields))
+	var required []string
+
+	for _, field := range fields {
+		properties[field.Name] = buildJSONSchemaField(field, opts)
+		if field.PresenceRatio >= opts.RequiredRatio {
+			required = append(required, field.Name)
+		}
+	}
+
+	return properties, required
+}
+
+// Construye la regla de un campo: bsonType con todos los tipos observados,
+// propiedades anidadas para documentos, items para arreglos y enum para
+// textos de baja cardinalidad
+func buildJSONSchemaField(field InferredField, opts ValidatorOptions) map[string]interface{} {
+	node := map[string]interface{}{}
+	setJSONSchemaBSONType(node, field.Types)
+
+	if hasObservedType(field.Types, "object") && len(field.Fields) > 0 {
+		properties, required := buildJSONSchemaProperties(field.Fields, opts)
+		node["properties"] = properties
+		if len(required) > 0 {
+			node["required"] = required
+		}
+	}
+
+	if hasObservedType(field.Types, "array") && len(field.ArrayElementTypes) > 0 {
+		items := map[string]interface{}{}
+		setJSONSchemaBSONType(items, field.ArrayElementTypes)
+		// Los campos de documentos dentro del arreglo se acumulan en el
+		// mismo nodo que los del documento embebido
+		if hasObservedType(field.ArrayElementTypes, "object") && len(field.Fields) > 0 {
+			properties, required := buildJSONSchemaProperties(field.Fields, opts)
+			items["properties"] = properties
+			if len(required) > 0 {
+				items["required"] = required
+			}
+		}
+		node["items"] = items
+	}
+
+	// Solo se genera enum si todos los valores observados fueron texto
+	if len(field.StringValues) > 0 && len(field.StringValues) <= opts.EnumMaxValues && onlyStringTypes(field.Types) {
+		enum := make([]interface{}, 0, len(field.StringValues)+1)
+		for _, v := range field.StringValues {
+			enum = append(enum, v)
+		}
+		if hasObservedType(field.Types, "null") {
+			enum = append(enum, nil)
+		}
+		node["enum"] = enum
+	}
+
+	return node
+}
+
+// Agrega bsonType con un único tipo o la lista de tipos observados. Si solo se
+// observó undefined no se agrega, ya que null no es un bsonType válido.
+func setJSONSchemaBSONType(node map[string]interface{}, types []ObservedType) {
+	var aliases []string
+	for _, t := range types {
+		// undefined está obsoleto y no es aceptado por $jsonSchema
+		if t.BSONType == "undefined" {
+			continue
+		}
+		aliases = append(aliases, t.BSONType)
+	}
+
+	switch len(aliases) {
+	case 0:
+	case 1:
+		node["bsonType"] = aliases[0]
+	default:
+		node["bsonType"] = aliases
+	}
+}
+
+func hasObservedType(types []ObservedType, bsonType string) bool {
+	for _, t := range types {
+		if t.BSONType == bsonType {
+			return true
+		}
+	}
+	return false
+}
+
+func onlyStringTypes(types []ObservedType) bool {
+	for _, t := range types {
+		if t.BSONType != "string" && t.BSONType != "null" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildMongoValidator(t *testing.T) {
+	schema := &InferredSchema{SampleSize: 10, DocumentCount: 10, Fields: []InferredField{
+		{Name: "_id", Path: "_id", PresenceRatio: 1, Types: []ObservedType{{"objectId", 10}}},
+		{Name: "estado", Path: "estado", PresenceRatio: 1, Types: []ObservedType{{"string", 8}, {"null", 2}},
+			StringValues: []string{"activo", "baja"}},
+		{Name: "codigo", Path: "codigo", PresenceRatio: 0.5, Types: []ObservedType{{"int", 3}, {"string", 2}},
+			StringValues: []string{"A"}},
+		{Name: "direccion", Path: "direccion", PresenceRatio: 0.9, Types: []ObservedType{{"object", 9}}, Fields: []InferredField{
+			{Name: "ciudad", Path: "direccion.ciudad", PresenceRatio: 1, Types: []ObservedType{{"string", 9}}},
+		}},
+		{Name: "items", Path: "items", PresenceRatio: 0.95, Types: []ObservedType{{"array", 9}, {"undefined", 1}},
+			ArrayElementTypes: []ObservedType{{"object", 12}}, Fields: []InferredField{
+				{Name: "sku", Path: "items.sku", PresenceRatio: 1, Types: []ObservedType{{"string", 12}}},
+				{Name: "nota", Path: "items.nota", PresenceRatio: 0.1, Types: []ObservedType{{"string", 1}}},
+			}},
+		{Name: "legado", Path: "legado", PresenceRatio: 0.2, Types: []ObservedType{{"undefined", 2}}},
+	}}
+
+	got := buildMongoValidator(schema, ValidatorOptions{RequiredRatio: 0.95, EnumMaxValues: 5})
+
+	want := map[string]interface{}{"$jsonSchema": map[string]interface{}{
+		"bsonType": "object",
+		"required": []string{"_id", "estado", "items"},
+		"properties": map[string]interface{}{
+			"_id": map[string]interface{}{"bsonType": "objectId"},
+			// El null observado se acepta también en el enum
+			"estado": map[string]interface{}{"bsonType": []string{"string", "null"}, "enum": []interface{}{"activo", "baja", nil}},
+			// Con otros tipos además de texto no se genera enum
+			"codigo": map[string]interface{}{"bsonType": []string{"int", "string"}},
+			"direccion": map[string]interface{}{
+				"bsonType":   "object",
+				"required":   []string{"ciudad"},
+				"properties": map[string]interface{}{"ciudad": map[string]interface{}{"bsonType": "string"}},
+			},
+			// undefined no es un bsonType válido en $jsonSchema
+			"items": map[string]interface{}{
+				"bsonType": "array",
+				"items": map[string]interface{}{
+					"bsonType": "object",
+					"required": []string{"sku"},
+					"properties": map[string]interface{}{
+						"sku":  map[string]interface{}{"bsonType": "string"},
+						"nota": map[string]interface{}{"bsonType": "string"},
+					},
+				},
+			},
+			// Solo se observó undefined: el campo se acepta con cualquier tipo
+			"legado": map[string]interface{}{},
+		},
+	}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("validador = %#v\nse esperaba %#v", got, want)
+	}
+}
+
+func TestInferStringValues(t *testing.T) {
+	var docs []bson.D
+	for i, estado := range []string{"activo", "baja", "activo", "activo", "baja", "pendiente"} {
+		docs = append(docs, bson.D{{Key: "estado", Value: estado}, {Key: "nombre", Value: fmt.Sprintf("cliente %d", i)}})
+	}
+	fields := inferTestFields(t, docs...)
+
+	if estado := findInferredField(fields, "estado"); !reflect.DeepEqual(estado.StringValues, []string{"activo", "baja", "pendiente"}) {
+		t.Errorf("valores de estado = %v", estado.StringValues)
+	}
+	// Cada nombre aparece una sola vez: no es de baja cardinalidad
+	if nombre := findInferredField(fields, "nombre"); nombre.StringValues != nil {
+		t.Errorf("valores de nombre = %v, se esperaba ninguno", nombre.StringValues)
+	}
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Función específica para extraer columnas de Oracle desde ALL_TAB_COLUMNS.
+// El owner de la tabla es el schema indicado con -schema.
+func extractOracleTableColumns(db *sql.DB, owner, tableName string) ([]Column, error) {
+	rowsColumns, err := db.Query(getOracleColumnsQuery(true), owner, tableName, owner, tableName)
+	if err != nil {
+		// IDENTITY_COLUMN solo existe desde Oracle 12c
+		rowsColumns, err = db.Query(getOracleColumnsQuery(false), owner, tableName, owner, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("error al consultar columnas: %v", err)
+		}
+	}
+	defer rowsColumns.Close()
+
+	var columns []Column
+
+	for rowsColumns.Next() {
+		var col Column
+		var nullable, charUsed, defaultValue sql.NullString
+		var charLength, precision, scale sql.NullInt32
+		var isPrimaryKey, isIdentity int
+
+		err := rowsColumns.Scan(
+			&col.ColumnName,
+			&col.DataType,
+			&nullable,
+			&charLength,
+			&charUsed,
+			&precision,
+			&scale,
+			&isPrimaryKey,
+			&isIdentity,
+			&defaultValue,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear columna: %v", err)
+		}
+
+		col.IsNullable = "YES"
+		if nullable.String == "N" {
+			col.IsNullable = "NO"
+		}
+		col.IsPrimaryKey = (isPrimaryKey == 1)
+		col.IsIdentity = (isIdentity == 1)
+
+		// DATA_DEFAULT es LONG y suele conservar el salto de línea final
+		col.DefaultValue = strings.TrimSpace(defaultValue.String)
+
+		setOracleColumnSize(&col, charLength, charUsed, precision, scale)
+
+		columns = append(columns, col)
+	}
+
+	if err = rowsColumns.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre columnas: %v", err)
+	}
+
+	return columns, nil
+}
+
+// Copia las longitudes, precisión y escala de ALL_TAB_COLUMNS a la columna
+func setOracleColumnSize(col *Column, charLength sql.NullInt32, charUsed sql.NullString, precision, scale sql.NullInt32) {
+	// CHAR_LENGTH es la longitud declarada; CHAR_USED indica si se mide
+	// en bytes (B) o en caracteres (C)
+	if charLength.Valid && charLength.Int32 > 0 {
+		col.MaxLength = int(charLength.Int32)
+		switch charUsed.String {
+		case "B":
+			col.LengthSemantics = "BYTE"
+		case "C":
+			col.LengthSemantics = "CHAR"
+		}
+	}
+
+	// NUMBER sin precisión es un número de precisión arbitraria; con
+	// escala y sin precisión (NUMBER(*,0), que es INTEGER) la escala es fija
+	if precision.Valid {
+		col.Precision = int(precision.Int32)
+	}
+	// En TIMESTAMP, DATA_SCALE son los dígitos de fracción de segundo
+	if scale.Valid && strings.HasPrefix(col.DataType, "timestamp") {
+		setDatetimePrecision(col, int(scale.Int32))
+	} else if scale.Valid {
+		col.Scale = int(scale.Int32)
+		col.PrecisionUnspecified = !precision.Valid && col.DataType == "number"
+	}
+}
+
+func getOracleColumnsQuery(withIdentity bool) string {
+	identity := "0"
+	if withIdentity {
+		identity = "CASE WHEN c.identity_column = 'YES' THEN 1 ELSE 0 END"
+	}
+
+	return fmt.Sprintf(`
+		SELECT
+			c.column_name,
+			LOWER(c.data_type) AS data_type,
+			c.nullable,
+			c.char_length,
+			c.char_used,
+			c.data_precision,
+			c.data_scale,
+			CASE WHEN pk.column_name IS NOT NULL THEN 1 ELSE 0 END AS is_primary_key,
+			%s AS is_identity,
+			c.data_default
+		FROM all_tab_columns c
+		LEFT JOIN (
+			SELECT cc.column_name
+			FROM all_constraints k
+			JOIN all_cons_columns cc
+				ON cc.owner = k.owner
+				AND cc.constraint_name = k.constraint_name
+			WHERE k.constraint_type = 'P'
+			AND k.owner = :1
+			AND k.table_name = :2
+		) pk ON pk.column_name = c.column_name
+		WHERE c.owner = :3
+		AND c.table_name = :4
+		ORDER BY c.column_id
+	`, identity)
+}
+
+// Función específica para extraer claves foráneas de Oracle. Oracle no
+// soporta ON UPDATE, por lo que siempre se informa NO ACTION.
+func extractOracleForeignKeys(db *sql.DB, owner, tableName string) ([]ForeignKey, error) {
+	query := `
+		SELECT
+			c.constraint_name,
+			cc.column_name,
+			rc.owner AS referenced_schema,
+			rc.table_name AS referenced_table,
+			rcc.column_name AS referenced_column,
+			c.delete_rule AS on_delete,
+			'NO ACTION' AS on_update
+		FROM all_constraints c
+		JOIN all_cons_columns cc
+			ON cc.owner = c.owner
+			AND cc.constraint_name = c.constraint_name
+		JOIN all_constraints rc
+			ON rc.owner = c.r_owner
+			AND rc.constraint_name = c.r_constraint_name
+		JOIN all_cons_columns rcc
+			ON rcc.owner = rc.owner
+			AND rcc.constraint_name = rc.constraint_name
+			AND rcc.position = cc.position
+		WHERE c.constraint_type = 'R'
+		AND c.owner = :1
+		AND c.table_name = :2
+		ORDER BY c.constraint_name, cc.position
+	`
+
+	rows, err := db.Query(query, owner, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar claves foráneas: %v", err)
+	}
+	defer rows.Close()
+
+	return scanForeignKeys(rows)
+}
+
+// Función específica para extraer índices de Oracle. Las columnas de índices
+// basados en funciones (incluidas las DESC) aparecen como columnas ocultas
+// SYS_NC...$, así que se usa la expresión de ALL_IND_EXPRESSIONS.
+func extractOracleIndexes(db *sql.DB, owner, tableName string) ([]Index, error) {
+	query := `
+		SELECT
+			i.index_name,
+			ic.column_name,
+			ic.descend,
+			ie.column_expression,
+			CASE WHEN i.uniqueness = 'UNIQUE' THEN 1 ELSE 0 END AS is_unique,
+			CASE WHEN k.constraint_type = 'P' THEN 1 ELSE 0 END AS is_primary_key,
+			CASE WHEN k.constraint_type = 'U' THEN 1 ELSE 0 END AS is_unique_constraint,
+			CASE WHEN i.index_type = 'IOT - TOP' THEN 1 ELSE 0 END AS is_clustered,
+			i.index_type
+		FROM all_indexes i
+		JOIN all_ind_columns ic
+			ON ic.index_owner = i.owner
+			AND ic.index_name = i.index_name
+		LEFT JOIN all_ind_expressions ie
+			ON ie.index_owner = ic.index_owner
+			AND ie.index_name = ic.index_name
+			AND ie.column_position = ic.column_position
+		LEFT JOIN all_constraints k
+			ON k.owner = i.table_owner
+			AND k.table_name = i.table_name
+			AND k.index_name = i.index_name
+			AND k.constraint_type IN ('P', 'U')
+		WHERE i.table_owner = :1
+		AND i.table_name = :2
+		AND i.index_type <> 'LOB'
+		ORDER BY i.index_name, ic.column_position
+	`
+
+	rows, err := db.Query(query, owner, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar índices: %v", err)
+	}
+	defer rows.Close()
+
+	var indexes []Index
+
+	for rows.Next() {
+		var indexName, columnName, descend, indexType string
+		var expression sql.NullString
+		var isUnique, isPrimaryKey, isUniqueConstraint, isClustered int
+
+		err := rows.Scan(
+			&indexName,
+			&columnName,
+			&descend,
+			&expression,
+			&isUnique,
+			&isPrimaryKey,
+			&isUniqueConstraint,
+			&isClustered,
+			&indexType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear índice: %v", err)
+		}
+
+		// Una columna DESC se guarda como expresión "COLUMNA" entre comillas
+		if expression.Valid {
+			columnName = strings.Trim(strings.TrimSpace(expression.String), `"`)
+		}
+
+		n := len(indexes)
+		if n == 0 || indexes[n-1].IndexName != indexName {
+			indexes = append(indexes, Index{
+				IndexName:          indexName,
+				Columns:            []IndexColumn{},
+				IsUnique:           isUnique == 1,
+				IsPrimaryKey:       isPrimaryKey == 1,
+				IsUniqueConstraint: isUniqueConstraint == 1,
+				IsClustered:        isClustered == 1,
+				IndexType:          indexType,
+			})
+			n++
+		}
+
+		indexes[n-1].Columns = append(indexes[n-1].Columns, IndexColumn{
+			ColumnName: columnName,
+			Order:      descend,
+		})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre índices: %v", err)
+	}
+
+	return indexes, nil
+}
+
+// Función específica para extraer vistas de Oracle. TEXT y QUERY son LONG,
+// que no se pueden combinar con UNION, así que las vistas materializadas se
+// consultan por separado.
+func extractOracleViews(db *sql.DB, owner string) ([]View, error) {
+	queries := []string{`
+		SELECT owner, view_name, text, 0 AS is_materialized
+		FROM all_views
+		WHERE owner = :1
+		ORDER BY view_name
+	`, `
+		SELECT owner, mview_name, query, 1 AS is_materialized
+		FROM all_mviews
+		WHERE owner = :1
+		ORDER BY mview_name
+	`}
+
+	var views []View
+	for _, query := range queries {
+		rows, err := db.Query(query, owner)
+		if err != nil {
+			return nil, fmt.Errorf("error al consultar vistas: %v", err)
+		}
+
+		found, err := scanViews(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, found...)
+	}
+
+	return addViewColumns(db, "oracle", views)
+}
+
+// Función específica para extraer procedimientos y funciones de Oracle que no
+// pertenecen a un paquete. El texto se arma con las líneas de ALL_SOURCE y
+// los parámetros salen de ALL_ARGUMENTS (la posición 0 es el retorno).
+func extractOracleRoutines(db *sql.DB, owner string) ([]Routine, error) {
+	query := `
+		SELECT
+			owner,
+			object_name,
+			object_name AS specific_name,
+			CASE
+				WHEN object_type = 'PROCEDURE' THEN 'procedure'
+				WHEN aggregate = 'YES' THEN 'aggregate'
+				WHEN pipelined = 'YES' THEN 'table_function'
+				ELSE 'scalar_function'
+			END AS routine_kind,
+			'' AS return_type,
+			'PL/SQL' AS routine_language,
+			NULL AS routine_definition
+		FROM all_procedures
+		WHERE owner = :1
+		AND object_type IN ('PROCEDURE', 'FUNCTION')
+		AND procedure_name IS NULL
+		ORDER BY object_name
+	`
+
+	rows, err := db.Query(query, owner)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar rutinas: %v", err)
+	}
+
+	routines, err := scanRoutines(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(routines) == 0 {
+		return routines, nil
+	}
+
+	byName := make(map[string]*Routine)
+	for i := range routines {
+		byName[routines[i].RoutineName] = &routines[i]
+	}
+
+	sourceRows, err := db.Query(`
+		SELECT name, text
+		FROM all_source
+		WHERE owner = :1
+		AND type IN ('PROCEDURE', 'FUNCTION')
+		ORDER BY name, line
+	`, owner)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar el código de las rutinas: %v", err)
+	}
+	defer sourceRows.Close()
+
+	for sourceRows.Next() {
+		var name string
+		var text sql.NullString
+		if err := sourceRows.Scan(&name, &text); err != nil {
+			return nil, fmt.Errorf("error al escanear el código de la rutina: %v", err)
+		}
+		if routine, ok := byName[name]; ok {
+			routine.Definition += text.String
+		}
+	}
+	if err = sourceRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre el código de las rutinas: %v", err)
+	}
+
+	argumentRows, err := db.Query(`
+		SELECT
+			object_name,
+			position,
+			in_out,
+			argument_name,
+			LOWER(data_type),
+			data_length,
+			data_precision,
+			data_scale
+		FROM all_arguments
+		WHERE owner = :1
+		AND package_name IS NULL
+		AND data_level = 0
+		ORDER BY object_name, position
+	`, owner)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar parámetros: %v", err)
+	}
+	defer argumentRows.Close()
+
+	for argumentRows.Next() {
+		var objectName string
+		var position int
+		var direction, name, dataType sql.NullString
+		var length, precision, scale sql.NullInt32
+
+		err := argumentRows.Scan(&objectName, &position, &direction, &name, &dataType, &length, &precision, &scale)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear parámetro: %v", err)
+		}
+
+		// Las rutinas sin parámetros tienen una fila sin tipo
+		routine, ok := byName[objectName]
+		if !ok || !dataType.Valid {
+			continue
+		}
+
+		param := RoutineParameter{
+			Name:      name.String,
+			Position:  position,
+			Direction: normalizeParameterDirection(direction.String),
+			DataType:  dataType.String,
+			Precision: int(precision.Int32),
+			Scale:     int(scale.Int32),
+		}
+		if strings.Contains(param.DataType, "char") || strings.Contains(param.DataType, "raw") {
+			param.MaxLength = int(length.Int32)
+		}
+
+		if position == 0 {
+			routine.ReturnType = describeParameterType(param, "oracle")
+			continue
+		}
+		routine.Parameters = append(routine.Parameters, param)
+	}
+	if err = argumentRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre parámetros: %v", err)
+	}
+
+	for i := range routines {
+		routines[i].Definition = strings.TrimSpace(routines[i].Definition)
+	}
+
+	return routines, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+// Valores de ALL_TAB_COLUMNS para cada tipo declarado
+func TestSetOracleColumnSize(t *testing.T) {
+	null := sql.NullInt32{}
+	n := func(v int32) sql.NullInt32 { return sql.NullInt32{Int32: v, Valid: true} }
+	used := func(v string) sql.NullString { return sql.NullString{String: v, Valid: v != ""} }
+	six, zero := 6, 0
+
+	tests := []struct {
+		declared   string
+		charLength sql.NullInt32
+		charUsed   sql.NullString
+		precision  sql.NullInt32
+		scale      sql.NullInt32
+		want       Column
+	}{
+		{"VARCHAR2(20 CHAR)", n(20), used("C"), null, null, Column{MaxLength: 20, LengthSemantics: "CHAR"}},
+		{"VARCHAR2(20 BYTE)", n(20), used("B"), null, null, Column{MaxLength: 20, LengthSemantics: "BYTE"}},
+		{"NVARCHAR2(10)", n(10), used("C"), null, null, Column{MaxLength: 10, LengthSemantics: "CHAR"}},
+		{"NUMBER(10,2)", n(0), used(""), n(10), n(2), Column{DataType: "number", Precision: 10, Scale: 2}},
+		{"NUMBER(5)", n(0), used(""), n(5), n(0), Column{DataType: "number", Precision: 5}},
+		{"NUMBER", n(0), used(""), null, null, Column{DataType: "number"}},
+		{"NUMBER(*,0)", n(0), used(""), null, n(0), Column{DataType: "number", PrecisionUnspecified: true}},
+		{"NUMBER(*,2)", n(0), used(""), null, n(2), Column{DataType: "number", Scale: 2, PrecisionUnspecified: true}},
+		{"DATE", n(0), used(""), null, null, Column{DataType: "date"}},
+		{"TIMESTAMP(6)", n(0), used(""), null, n(6), Column{DataType: "timestamp(6)", DatetimePrecision: &six}},
+		{"TIMESTAMP(0)", n(0), used(""), null, n(0), Column{DataType: "timestamp(0)", DatetimePrecision: &zero}},
+	}
+
+	for _, tt := range tests {
+		col := Column{DataType: tt.want.DataType}
+		setOracleColumnSize(&col, tt.charLength, tt.charUsed, tt.precision, tt.scale)
+		if !reflect.DeepEqual(col, tt.want) {
+			t.Errorf("%s: columna = %+v, se esperaba %+v", tt.declared, col, tt.want)
+		}
+	}
+}
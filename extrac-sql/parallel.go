@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Procesa n objetos (tablas o colecciones) con hasta workers goroutines que
+// comparten la misma conexión. Cada llamada a process guarda su resultado en
+// la posición i, así el orden de salida no depende del orden en que terminan.
+// Un error no detiene a los demás objetos; se devuelve en su misma posición.
+func runWorkerPool(n, workers int, process func(i int) error) []error {
+	errs := make([]error, n)
+
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			errs[i] = process(i)
+		}
+		return errs
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = process(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return errs
+}
+
+// Tabla o colección que no se pudo extraer. El resto del esquema se guarda
+// igual y el programa termina con código 1.
+type ObjectError struct {
+	Object string `json:"object"`
+	Error  string `json:"error"`
+}
+
+// Devuelve los objetos que fallaron, en el orden en que se listaron, con su
+// nombre completo según names. Cada fallo se avisa en progress.
+func collectObjectErrors(progress io.Writer, errs []error, names func(i int) string) []ObjectError {
+	var failed []ObjectError
+	for i, err := range errs {
+		if err != nil {
+			fmt.Fprintf(progress, "  ⚠️  No se pudo extraer %s: %v\n", names(i), err)
+			failed = append(failed, ObjectError{Object: names(i), Error: err.Error()})
+		}
+	}
+	return failed
+}
+
+// Une los objetos que fallaron en un error, para los modos que no pueden
+// trabajar con un esquema incompleto (check y migrate). Devuelve nil si no
+// falló ninguno.
+func joinObjectErrors(failed []ObjectError) error {
+	if len(failed) == 0 {
+		return nil
+	}
+
+	var messages []string
+	for _, f := range failed {
+		messages = append(messages, f.Error)
+	}
+	if len(messages) == 1 {
+		return fmt.Errorf("%s", messages[0])
+	}
+	return fmt.Errorf("%d objetos no se pudieron extraer:\n  %s", len(messages), strings.Join(messages, "\n  "))
+}
@@ -0,0 +1,503 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Tipos de rutina
+const (
+	routineProcedure      = "procedure"
+	routineScalarFunction = "scalar_function"
+	routineTableFunction  = "table_function"
+	routineAggregate      = "aggregate"
+)
+
+// Tipo de retorno declarado en el texto de una función de Sybase
+var sybaseReturnsClause = regexp.MustCompile(`(?is)\bRETURNS\s+([a-z_]\w*(?:\s*\(\s*\d+\s*(?:,\s*\d+\s*)?\))?)`)
+
+// Estructura para almacenar un procedimiento almacenado o una función.
+// Definition es el texto fuente tal como lo guarda el motor.
+type Routine struct {
+	RoutineName string             `json:"routineName"`
+	Schema      string             `json:"schema"`
+	Kind        string             `json:"kind"`
+	Parameters  []RoutineParameter `json:"parameters,omitempty"`
+	ReturnType  string             `json:"returnType,omitempty"`
+	Language    string             `json:"language,omitempty"`
+	Definition  string             `json:"definition,omitempty"`
+	// Identifica cada sobrecarga en PostgreSQL; en el resto es el nombre
+	specificName string
+}
+
+// Direction es IN, OUT o INOUT
+type RoutineParameter struct {
+	Name      string `json:"name"`
+	Position  int    `json:"position"`
+	Direction string `json:"direction"`
+	DataType  string `json:"dataType"`
+	MaxLength int    `json:"maxLength,omitempty"`
+	Precision int    `json:"precision,omitempty"`
+	Scale     int    `json:"scale,omitempty"`
+}
+
+func extractRoutines(db *sql.DB, dbType, schemaName string) ([]Routine, error) {
+	switch dbType {
+	case "sybase":
+		// Para Sybase, construimos la consulta dinámicamente sin parámetros
+		return extractSybaseRoutines(db, schemaName)
+	case "oracle":
+		return extractOracleRoutines(db, schemaName)
+	case "sqlite":
+		// SQLite no tiene procedimientos ni funciones almacenadas
+		return nil, nil
+	}
+
+	var rows *sql.Rows
+	var err error
+
+	switch dbType {
+	case "sqlserver":
+		rows, err = db.Query(getRoutinesQuery(dbType), sql.Named("schema", schemaName))
+	case "mysql":
+		rows, err = db.Query(getRoutinesQuery(dbType), schemaName)
+	case "postgres":
+		rows, err = db.Query(getRoutinesQuery(dbType), schemaName)
+		if err != nil {
+			// pg_proc.prokind solo existe desde PostgreSQL 11
+			rows, err = db.Query(getPostgresLegacyRoutinesQuery(), schemaName)
+		}
+	default:
+		return nil, fmt.Errorf("tipo de base de datos no soportado: %s", dbType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar rutinas: %v", err)
+	}
+
+	routines, err := scanRoutines(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	err = addRoutineParameters(db, dbType, schemaName, routines)
+	if err != nil {
+		return nil, err
+	}
+
+	return routines, nil
+}
+
+// Todas las consultas devuelven schema, nombre, nombre específico, tipo de
+// rutina, tipo de retorno, lenguaje y definición
+func getRoutinesQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		// El tipo de retorno de las funciones escalares viene en
+		// INFORMATION_SCHEMA.PARAMETERS con ORDINAL_POSITION = 0
+		return `
+			SELECT
+				s.name AS ROUTINE_SCHEMA,
+				o.name AS ROUTINE_NAME,
+				o.name AS SPECIFIC_NAME,
+				CASE
+					WHEN o.type IN ('P', 'PC') THEN 'procedure'
+					WHEN o.type IN ('FN', 'FS') THEN 'scalar_function'
+					WHEN o.type IN ('IF', 'TF', 'FT') THEN 'table_function'
+					ELSE 'aggregate'
+				END AS ROUTINE_KIND,
+				CASE WHEN o.type IN ('IF', 'TF', 'FT') THEN 'TABLE' ELSE '' END AS RETURN_TYPE,
+				CASE WHEN o.type IN ('PC', 'FS', 'FT', 'AF') THEN 'CLR' ELSE 'SQL' END AS ROUTINE_LANGUAGE,
+				m.definition AS ROUTINE_DEFINITION
+			FROM sys.objects o
+			JOIN sys.schemas s ON s.schema_id = o.schema_id
+			LEFT JOIN sys.sql_modules m ON m.object_id = o.object_id
+			WHERE o.type IN ('P', 'PC', 'FN', 'FS', 'IF', 'TF', 'FT', 'AF')
+			AND o.is_ms_shipped = 0
+			AND s.name = @schema
+			ORDER BY o.name
+		`
+	case "mysql":
+		return `
+			SELECT
+				ROUTINE_SCHEMA,
+				ROUTINE_NAME,
+				SPECIFIC_NAME,
+				CASE WHEN ROUTINE_TYPE = 'PROCEDURE' THEN 'procedure' ELSE 'scalar_function' END AS ROUTINE_KIND,
+				COALESCE(DTD_IDENTIFIER, '') AS RETURN_TYPE,
+				ROUTINE_BODY,
+				ROUTINE_DEFINITION
+			FROM INFORMATION_SCHEMA.ROUTINES
+			WHERE ROUTINE_SCHEMA = ?
+			ORDER BY ROUTINE_NAME
+		`
+	case "postgres":
+		// Se excluyen las funciones instaladas por extensiones; las funciones
+		// agregadas no tienen definición en pg_get_functiondef
+		return `
+			SELECT
+				n.nspname,
+				p.proname,
+				p.proname || '_' || p.oid,
+				CASE
+					WHEN p.prokind = 'p' THEN 'procedure'
+					WHEN p.prokind IN ('a', 'w') THEN 'aggregate'
+					WHEN p.proretset THEN 'table_function'
+					ELSE 'scalar_function'
+				END,
+				CASE WHEN p.prokind = 'p' THEN '' ELSE pg_get_function_result(p.oid) END,
+				l.lanname,
+				CASE WHEN p.prokind IN ('a', 'w') THEN NULL ELSE pg_get_functiondef(p.oid) END
+			FROM pg_proc p
+			JOIN pg_namespace n ON n.oid = p.pronamespace
+			JOIN pg_language l ON l.oid = p.prolang
+			WHERE n.nspname = $1
+			AND NOT EXISTS (
+				SELECT 1 FROM pg_depend d
+				WHERE d.classid = 'pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e'
+			)
+			ORDER BY p.proname, p.oid
+		`
+	default:
+		return ""
+	}
+}
+
+// Antes de PostgreSQL 11 no había procedimientos y las funciones agregadas
+// se marcaban con proisagg
+func getPostgresLegacyRoutinesQuery() string {
+	return `
+		SELECT
+			n.nspname,
+			p.proname,
+			p.proname || '_' || p.oid,
+			CASE
+				WHEN p.proisagg THEN 'aggregate'
+				WHEN p.proretset THEN 'table_function'
+				ELSE 'scalar_function'
+			END,
+			pg_get_function_result(p.oid),
+			l.lanname,
+			CASE WHEN p.proisagg THEN NULL ELSE pg_get_functiondef(p.oid) END
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		JOIN pg_language l ON l.oid = p.prolang
+		WHERE n.nspname = $1
+		AND NOT EXISTS (
+			SELECT 1 FROM pg_depend d
+			WHERE d.classid = 'pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e'
+		)
+		ORDER BY p.proname, p.oid
+	`
+}
+
+func scanRoutines(rows *sql.Rows) ([]Routine, error) {
+	defer rows.Close()
+
+	var routines []Routine
+	for rows.Next() {
+		var routine Routine
+		var returnType, language, definition sql.NullString
+
+		err := rows.Scan(&routine.Schema, &routine.RoutineName, &routine.specificName, &routine.Kind,
+			&returnType, &language, &definition)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear rutina: %v", err)
+		}
+
+		routine.ReturnType = returnType.String
+		routine.Language = strings.ToUpper(language.String)
+		// SQL Server devuelve NULL para las rutinas creadas WITH ENCRYPTION
+		routine.Definition = strings.TrimSpace(definition.String)
+		routines = append(routines, routine)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre rutinas: %v", err)
+	}
+
+	return routines, nil
+}
+
+// Los parámetros de todo el schema se consultan de una vez y se asignan por
+// nombre específico. La posición 0 es el valor de retorno de una función.
+func addRoutineParameters(db *sql.DB, dbType, schemaName string, routines []Routine) error {
+	if len(routines) == 0 {
+		return nil
+	}
+
+	var rows *sql.Rows
+	var err error
+
+	switch dbType {
+	case "sqlserver":
+		rows, err = db.Query(getRoutineParametersQuery(dbType), sql.Named("schema", schemaName))
+	case "mysql", "postgres":
+		rows, err = db.Query(getRoutineParametersQuery(dbType), schemaName)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error al consultar parámetros: %v", err)
+	}
+	defer rows.Close()
+
+	bySpecificName := make(map[string]*Routine)
+	for i := range routines {
+		bySpecificName[routines[i].specificName] = &routines[i]
+	}
+
+	for rows.Next() {
+		var specificName string
+		var param RoutineParameter
+		var maxLength, precision, scale sql.NullInt32
+
+		err := rows.Scan(&specificName, &param.Position, &param.Direction, &param.Name, &param.DataType,
+			&maxLength, &precision, &scale)
+		if err != nil {
+			return fmt.Errorf("error al escanear parámetro: %v", err)
+		}
+
+		routine, ok := bySpecificName[specificName]
+		if !ok {
+			continue
+		}
+
+		if maxLength.Valid {
+			param.MaxLength = int(maxLength.Int32)
+		}
+		if precision.Valid {
+			param.Precision = int(precision.Int32)
+		}
+		if scale.Valid {
+			param.Scale = int(scale.Int32)
+		}
+
+		if param.Position == 0 {
+			if routine.ReturnType == "" {
+				routine.ReturnType = describeParameterType(param, dbType)
+			}
+			continue
+		}
+
+		param.Direction = normalizeParameterDirection(param.Direction)
+		routine.Parameters = append(routine.Parameters, param)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterando sobre parámetros: %v", err)
+	}
+
+	return nil
+}
+
+// Todas las consultas devuelven nombre específico, posición, dirección,
+// nombre, tipo, longitud, precisión y escala
+func getRoutineParametersQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				SPECIFIC_NAME,
+				ORDINAL_POSITION,
+				COALESCE(PARAMETER_MODE, 'IN'),
+				COALESCE(PARAMETER_NAME, ''),
+				DATA_TYPE,
+				CHARACTER_MAXIMUM_LENGTH,
+				NUMERIC_PRECISION,
+				NUMERIC_SCALE
+			FROM INFORMATION_SCHEMA.PARAMETERS
+			WHERE SPECIFIC_SCHEMA = @schema
+			ORDER BY SPECIFIC_NAME, ORDINAL_POSITION
+		`
+	case "mysql":
+		return `
+			SELECT
+				SPECIFIC_NAME,
+				ORDINAL_POSITION,
+				COALESCE(PARAMETER_MODE, 'IN'),
+				COALESCE(PARAMETER_NAME, ''),
+				DATA_TYPE,
+				CHARACTER_MAXIMUM_LENGTH,
+				NUMERIC_PRECISION,
+				NUMERIC_SCALE
+			FROM INFORMATION_SCHEMA.PARAMETERS
+			WHERE SPECIFIC_SCHEMA = ?
+			ORDER BY SPECIFIC_NAME, ORDINAL_POSITION
+		`
+	case "postgres":
+		return `
+			SELECT
+				specific_name,
+				ordinal_position,
+				COALESCE(parameter_mode, 'IN'),
+				COALESCE(parameter_name, ''),
+				-- Los tipos de arreglos y de usuario distinguen las sobrecargas
+				CASE
+					WHEN data_type IN ('ARRAY', 'USER-DEFINED')
+					THEN format_type(format('%I.%I', udt_schema, udt_name)::regtype, NULL)
+					ELSE data_type
+				END AS data_type,
+				character_maximum_length,
+				numeric_precision,
+				numeric_scale
+			FROM information_schema.parameters
+			WHERE specific_schema = $1
+			ORDER BY specific_name, ordinal_position
+		`
+	default:
+		return ""
+	}
+}
+
+// Función específica para extraer rutinas de Sybase (sin parámetros). El
+// texto se guarda en syscomments en fragmentos ordenados por colid y los
+// parámetros están en syscolumns de la propia rutina.
+func extractSybaseRoutines(db *sql.DB, schemaName string) ([]Routine, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			user_name(o.uid) as routine_schema,
+			o.name as routine_name,
+			CASE WHEN o.type = 'SF' THEN 'scalar_function' ELSE 'procedure' END as routine_kind,
+			c.text
+		FROM sysobjects o
+		JOIN syscomments c ON c.id = o.id
+		WHERE o.type IN ('P', 'SF')
+		AND user_name(o.uid) = '%s'
+		ORDER BY o.name, c.colid
+	`, schemaName)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar rutinas: %v", err)
+	}
+	defer rows.Close()
+
+	var routines []Routine
+	for rows.Next() {
+		var routineSchema, routineName, kind string
+		var text sql.NullString
+
+		err := rows.Scan(&routineSchema, &routineName, &kind, &text)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear rutina: %v", err)
+		}
+
+		// Los fragmentos de una misma rutina llegan consecutivos
+		if n := len(routines); n > 0 && routines[n-1].RoutineName == routineName {
+			routines[n-1].Definition += text.String
+			continue
+		}
+		routines = append(routines, Routine{
+			RoutineName: routineName,
+			Schema:      routineSchema,
+			Kind:        kind,
+			Language:    "SQL",
+			Definition:  text.String,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre rutinas: %v", err)
+	}
+	rows.Close()
+
+	for i := range routines {
+		routines[i].Definition = strings.TrimSpace(routines[i].Definition)
+
+		// syscolumns no guarda el tipo de retorno; se toma de la cláusula RETURNS
+		if routines[i].Kind == routineScalarFunction {
+			if match := sybaseReturnsClause.FindStringSubmatch(routines[i].Definition); match != nil {
+				routines[i].ReturnType = strings.ToLower(strings.Join(strings.Fields(match[1]), ""))
+			}
+		}
+
+		routines[i].Parameters, err = getSybaseRoutineParameters(db, schemaName, routines[i].RoutineName)
+		if err != nil {
+			return nil, fmt.Errorf("error al extraer parámetros para rutina %s: %v", routines[i].RoutineName, err)
+		}
+	}
+
+	return routines, nil
+}
+
+// En syscolumns status2 indica la dirección: 2 salida, 4 entrada y salida
+func getSybaseRoutineParameters(db *sql.DB, schemaName, routineName string) ([]RoutineParameter, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			c.name as parameter_name,
+			c.colid as position,
+			CASE
+				WHEN c.status2 & 4 = 4 THEN 'INOUT'
+				WHEN c.status2 & 2 = 2 THEN 'OUT'
+				ELSE 'IN'
+			END as direction,
+			t.name as data_type,
+			c.length,
+			c.prec,
+			c.scale
+		FROM syscolumns c
+		JOIN systypes t ON c.usertype = t.usertype
+		WHERE c.id = object_id('%s.%s')
+		ORDER BY c.colid
+	`, schemaName, routineName)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar parámetros: %v", err)
+	}
+	defer rows.Close()
+
+	var params []RoutineParameter
+	for rows.Next() {
+		var param RoutineParameter
+		var length, prec, scale sql.NullInt32
+
+		err := rows.Scan(&param.Name, &param.Position, &param.Direction, &param.DataType, &length, &prec, &scale)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear parámetro: %v", err)
+		}
+
+		if length.Valid {
+			param.MaxLength = int(length.Int32)
+		}
+		if prec.Valid {
+			param.Precision = int(prec.Int32)
+		}
+		if scale.Valid {
+			param.Scale = int(scale.Int32)
+		}
+
+		params = append(params, param)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre parámetros: %v", err)
+	}
+
+	return params, nil
+}
+
+// Unifica las variantes de los catálogos (IN/OUT, IN OUT, INOUT)
+func normalizeParameterDirection(direction string) string {
+	direction = strings.ToUpper(strings.Join(strings.Fields(direction), ""))
+	switch direction {
+	case "IN/OUT", "INOUT":
+		return "INOUT"
+	case "OUT":
+		return "OUT"
+	case "VARIADIC":
+		return "VARIADIC"
+	default:
+		return "IN"
+	}
+}
+
+func describeParameterType(param RoutineParameter, dbType string) string {
+	return describeColumnType(Column{
+		DataType:  param.DataType,
+		MaxLength: param.MaxLength,
+		Precision: param.Precision,
+		Scale:     param.Scale,
+	}, dbType)
+}
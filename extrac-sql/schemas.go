@@ -0,0 +1,211 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Schemas de sistema que se excluyen al expandir patrones; pueden extraerse
+// igual indicándolos por su nombre exacto
+var systemSchemas = map[string][]string{
+	"sqlserver": {"sys", "INFORMATION_SCHEMA", "guest"},
+	"mysql":     {"information_schema", "mysql", "performance_schema", "sys"},
+	"postgres":  {"pg_catalog", "information_schema", "pg_toast"},
+	"oracle": {"SYS", "SYSTEM", "OUTLN", "DBSNMP", "XDB", "MDSYS", "CTXSYS", "ORDSYS", "ORDDATA",
+		"WMSYS", "EXFSYS", "OLAPSYS", "LBACSYS", "DVSYS", "AUDSYS", "GSMADMIN_INTERNAL", "APPQOSSYS"},
+}
+
+// Tablas, vistas, rutinas y secuencias extraídas de un schema
+type SchemaSummary struct {
+	SchemaName string   `json:"schemaName"`
+	Tables     []string `json:"tables"`
+	Views      []string `json:"views,omitempty"`
+	Routines   []string `json:"routines,omitempty"`
+	Sequences  []string `json:"sequences,omitempty"`
+}
+
+// Devuelve los schemas a extraer según -schema: un nombre, una lista separada
+// por comas, patrones glob (ventas_*) o * para todos los schemas de usuario.
+// Los nombres exactos se usan sin consultar el catálogo.
+func resolveSchemas(db *sql.DB, dbType, value string) ([]string, error) {
+	// SQLite no tiene schemas de usuario
+	if dbType == "sqlite" {
+		return []string{sqliteSchema}, nil
+	}
+
+	patterns := splitNameList(value)
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no se indicó ningún schema")
+	}
+
+	var available []string
+	for _, pattern := range patterns {
+		if isSchemaPattern(pattern) {
+			var err error
+			available, err = listSchemas(db, dbType)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	var resolved []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			resolved = append(resolved, name)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if !isSchemaPattern(pattern) {
+			add(pattern)
+			continue
+		}
+		for _, name := range available {
+			if matchesAnyPattern([]string{pattern}, name) {
+				add(name)
+			}
+		}
+	}
+
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("ningún schema coincide con: %s", value)
+	}
+
+	return resolved, nil
+}
+
+func splitNameList(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func isSchemaPattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// Lista los schemas de usuario de la base, sin los schemas de sistema
+func listSchemas(db *sql.DB, dbType string) ([]string, error) {
+	rows, err := db.Query(getSchemasQuery(dbType))
+	if err != nil && dbType == "oracle" {
+		// ORACLE_MAINTAINED solo existe desde Oracle 12c
+		rows, err = db.Query(`SELECT DISTINCT owner FROM all_tables ORDER BY owner`)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar schemas: %v", err)
+	}
+	defer rows.Close()
+
+	var schemas []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("error al escanear schema: %v", err)
+		}
+		if !isSystemSchema(dbType, name) {
+			schemas = append(schemas, name)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre schemas: %v", err)
+	}
+
+	sort.Strings(schemas)
+	return schemas, nil
+}
+
+func getSchemasQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		// Los schemas de los roles fijos (db_owner, db_datareader...) tienen
+		// schema_id desde 16384
+		return `
+			SELECT name
+			FROM sys.schemas
+			WHERE schema_id < 16384
+		`
+	case "sybase":
+		// Sybase no tiene schemas; son los dueños de las tablas de usuario
+		return `
+			SELECT DISTINCT user_name(uid)
+			FROM sysobjects
+			WHERE type = 'U'
+		`
+	case "mysql":
+		return `
+			SELECT SCHEMA_NAME
+			FROM INFORMATION_SCHEMA.SCHEMATA
+		`
+	case "postgres":
+		return `
+			SELECT nspname
+			FROM pg_namespace
+			WHERE nspname NOT LIKE 'pg\_temp\_%'
+			AND nspname NOT LIKE 'pg\_toast\_temp\_%'
+		`
+	case "oracle":
+		return `
+			SELECT username
+			FROM all_users
+			WHERE oracle_maintained = 'N'
+		`
+	default:
+		return ""
+	}
+}
+
+func isSystemSchema(dbType, name string) bool {
+	for _, system := range systemSchemas[dbType] {
+		if strings.EqualFold(system, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema por defecto del esquema extraído: el del motor si está entre los
+// extraídos, o el primero
+func defaultExtractedSchema(config Config, schemaNames []string) string {
+	preferred := defaultSchemaFor(config.DBType)
+	switch config.DBType {
+	case "mysql":
+		preferred = config.Database
+	case "oracle":
+		preferred = strings.ToUpper(config.User)
+	}
+
+	for _, name := range schemaNames {
+		if strings.EqualFold(name, preferred) {
+			return name
+		}
+	}
+	return schemaNames[0]
+}
+
+func summarizeSchema(schemaName string, tables []Table, views []View, routines []Routine, sequences []Sequence) SchemaSummary {
+	summary := SchemaSummary{SchemaName: schemaName, Tables: []string{}}
+	for _, table := range tables {
+		summary.Tables = append(summary.Tables, table.TableName)
+	}
+	for _, view := range views {
+		summary.Views = append(summary.Views, view.ViewName)
+	}
+	for _, routine := range routines {
+		summary.Routines = append(summary.Routines, routine.RoutineName)
+	}
+	for _, seq := range sequences {
+		summary.Sequences = append(summary.Sequences, seq.SequenceName)
+	}
+	return summary
+}
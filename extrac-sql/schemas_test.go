@@ -0,0 +1,44 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Los nombres exactos se resuelven sin consultar el catálogo, así que no se
+// necesita una conexión
+func TestResolveSchemas(t *testing.T) {
+	tests := []struct {
+		name    string
+		dbType  string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"sqlite usa main", "sqlite", "ventas", []string{"main"}, false},
+		{"un schema", "postgres", "public", []string{"public"}, false},
+		{"lista con espacios", "postgres", "ventas, compras ,public", []string{"ventas", "compras", "public"}, false},
+		{"sin repetidos", "sqlserver", "dbo,DBO,ventas,dbo", []string{"dbo", "ventas"}, false},
+		{"vacío", "postgres", " , ", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSchemas(nil, tt.dbType, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, se esperaba error: %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveSchemas(%q) = %q, se esperaba %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSchemaPattern(t *testing.T) {
+	for value, want := range map[string]bool{"ventas": false, "ventas_*": true, "*": true, "v?": true, "[ab]*": true} {
+		if got := isSchemaPattern(value); got != want {
+			t.Errorf("isSchemaPattern(%q) = %v, se esperaba %v", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Detalle de una columna identity. Generation es ALWAYS (no admite valores
+// explícitos sin IDENTITY_INSERT u OVERRIDING), BY DEFAULT o SEQUENCE para
+// las columnas serial de PostgreSQL. CurrentValue es el último valor
+// generado, si el motor lo informa.
+type IdentityInfo struct {
+	Generation   string `json:"generation"`
+	Seed         int64  `json:"seed"`
+	Increment    int64  `json:"increment"`
+	CurrentValue *int64 `json:"currentValue,omitempty"`
+	// Secuencia que respalda la columna en PostgreSQL y Oracle
+	Sequence string `json:"sequence,omitempty"`
+}
+
+// Estructura para almacenar una secuencia. OwnedBy es tabla.columna cuando
+// la secuencia pertenece a una columna serial o identity.
+type Sequence struct {
+	SequenceName string `json:"sequenceName"`
+	Schema       string `json:"schema"`
+	DataType     string `json:"dataType,omitempty"`
+	StartValue   int64  `json:"startValue"`
+	Increment    int64  `json:"increment"`
+	MinValue     *int64 `json:"minValue,omitempty"`
+	MaxValue     *int64 `json:"maxValue,omitempty"`
+	Cycle        bool   `json:"cycle"`
+	CurrentValue *int64 `json:"currentValue,omitempty"`
+	OwnedBy      string `json:"ownedBy,omitempty"`
+}
+
+// Completa Identity en las columnas identity de la tabla
+func addIdentityDetails(db *sql.DB, dbType, schemaName, tableName string, columns []Column) error {
+	hasIdentity := false
+	for _, col := range columns {
+		hasIdentity = hasIdentity || col.IsIdentity
+	}
+	if !hasIdentity {
+		return nil
+	}
+
+	// Sybase no admite semilla ni incremento y SQLite asigna el siguiente rowid
+	if dbType == "sybase" || dbType == "sqlite" {
+		generation := "ALWAYS"
+		if dbType == "sqlite" {
+			generation = "BY DEFAULT"
+		}
+		for i := range columns {
+			if columns[i].IsIdentity {
+				columns[i].Identity = &IdentityInfo{Generation: generation, Seed: 1, Increment: 1}
+			}
+		}
+		return nil
+	}
+
+	query := getIdentityQuery(dbType)
+	var rows *sql.Rows
+	var err error
+
+	switch dbType {
+	case "sqlserver":
+		rows, err = db.Query(query, sql.Named("schema", schemaName), sql.Named("table", tableName))
+	case "mysql", "postgres", "oracle":
+		rows, err = db.Query(query, schemaName, tableName)
+	default:
+		return fmt.Errorf("tipo de base de datos no soportado: %s", dbType)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error al consultar identidad: %v", err)
+	}
+	defer rows.Close()
+
+	byName := make(map[string]*Column)
+	for i := range columns {
+		byName[columns[i].ColumnName] = &columns[i]
+	}
+
+	for rows.Next() {
+		var columnName, generation string
+		var seed, increment, currentValue sql.NullInt64
+		var sequence sql.NullString
+
+		err := rows.Scan(&columnName, &generation, &seed, &increment, &currentValue, &sequence)
+		if err != nil {
+			return fmt.Errorf("error al escanear identidad: %v", err)
+		}
+
+		col, ok := byName[columnName]
+		if !ok {
+			continue
+		}
+
+		col.IsIdentity = true
+		col.Identity = newIdentityInfo(generation, seed, increment, currentValue, sequence)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterando sobre identidad: %v", err)
+	}
+
+	return nil
+}
+
+// La semilla y el incremento valen 1 si el motor no los informa
+func newIdentityInfo(generation string, seed, increment, currentValue sql.NullInt64, sequence sql.NullString) *IdentityInfo {
+	identity := &IdentityInfo{Generation: generation, Seed: 1, Increment: 1, Sequence: sequence.String}
+	if seed.Valid {
+		identity.Seed = seed.Int64
+	}
+	if increment.Valid {
+		identity.Increment = increment.Int64
+	}
+	if currentValue.Valid {
+		value := currentValue.Int64
+		identity.CurrentValue = &value
+	}
+	return identity
+}
+
+// Todas las consultas devuelven columna, generación, semilla, incremento,
+// valor actual y secuencia
+func getIdentityQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				ic.name AS COLUMN_NAME,
+				'ALWAYS' AS GENERATION,
+				CAST(ic.seed_value AS bigint) AS SEED,
+				CAST(ic.increment_value AS bigint) AS INCREMENT,
+				CAST(ic.last_value AS bigint) AS CURRENT_VALUE,
+				NULL AS SEQUENCE_NAME
+			FROM sys.identity_columns ic
+			WHERE ic.object_id = OBJECT_ID(QUOTENAME(@schema) + '.' + QUOTENAME(@table))
+		`
+	case "mysql":
+		// La semilla y el incremento de AUTO_INCREMENT son variables del servidor
+		return `
+			SELECT
+				COLUMN_NAME,
+				'BY DEFAULT' AS GENERATION,
+				@@auto_increment_offset AS SEED,
+				@@auto_increment_increment AS INCREMENT,
+				NULL AS CURRENT_VALUE,
+				NULL AS SEQUENCE_NAME
+			FROM INFORMATION_SCHEMA.COLUMNS
+			WHERE TABLE_SCHEMA = ?
+			AND TABLE_NAME = ?
+			AND EXTRA LIKE '%auto_increment%'
+		`
+	case "postgres":
+		// pg_get_serial_sequence resuelve tanto las columnas serial como las
+		// GENERATED AS IDENTITY; attidentity está vacío en las serial
+		return `
+			SELECT
+				a.attname,
+				CASE a.attidentity WHEN 'a' THEN 'ALWAYS' WHEN 'd' THEN 'BY DEFAULT' ELSE 'SEQUENCE' END,
+				s.start_value,
+				s.increment_by,
+				s.last_value,
+				s.schemaname || '.' || s.sequencename
+			FROM pg_attribute a
+			JOIN pg_sequences s
+				ON format('%I.%I', s.schemaname, s.sequencename)::regclass =
+					pg_get_serial_sequence(format('%I.%I', $1::text, $2::text), a.attname)::regclass
+			WHERE a.attrelid = format('%I.%I', $1::text, $2::text)::regclass
+			AND a.attnum > 0
+			AND NOT a.attisdropped
+		`
+	case "oracle":
+		// LAST_NUMBER es el siguiente valor guardado en disco, no el último
+		// generado, así que no se informa como valor actual
+		return `
+			SELECT
+				ic.column_name,
+				ic.generation_type,
+				TO_NUMBER(REGEXP_SUBSTR(ic.identity_options, 'START WITH: (-?[0-9]+)', 1, 1, NULL, 1)),
+				s.increment_by,
+				NULL,
+				ic.sequence_name
+			FROM all_tab_identity_cols ic
+			JOIN all_sequences s
+				ON s.sequence_owner = ic.owner
+				AND s.sequence_name = ic.sequence_name
+			WHERE ic.owner = :1
+			AND ic.table_name = :2
+		`
+	default:
+		return ""
+	}
+}
+
+// Secuencias del schema; solo PostgreSQL y SQL Server
+func extractSequences(db *sql.DB, dbType, schemaName string) ([]Sequence, error) {
+	var rows *sql.Rows
+	var err error
+
+	switch dbType {
+	case "sqlserver":
+		rows, err = db.Query(getSequencesQuery(dbType), sql.Named("schema", schemaName))
+	case "postgres":
+		rows, err = db.Query(getSequencesQuery(dbType), schemaName)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar secuencias: %v", err)
+	}
+	defer rows.Close()
+
+	var sequences []Sequence
+	for rows.Next() {
+		var seq Sequence
+		var minValue, maxValue, currentValue sql.NullInt64
+		var cycle int
+
+		err := rows.Scan(&seq.Schema, &seq.SequenceName, &seq.DataType, &seq.StartValue, &seq.Increment,
+			&minValue, &maxValue, &cycle, &currentValue, &seq.OwnedBy)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear secuencia: %v", err)
+		}
+
+		seq.Cycle = (cycle == 1)
+		seq.MinValue = nullInt64Pointer(minValue)
+		seq.MaxValue = nullInt64Pointer(maxValue)
+		seq.CurrentValue = nullInt64Pointer(currentValue)
+		sequences = append(sequences, seq)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre secuencias: %v", err)
+	}
+
+	return sequences, nil
+}
+
+// Todas las consultas devuelven schema, nombre, tipo, inicio, incremento,
+// mínimo, máximo, 1 si es cíclica, valor actual y columna propietaria
+func getSequencesQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		// Los límites de secuencias decimal pueden superar el rango de bigint
+		return `
+			SELECT
+				s.name AS SEQUENCE_SCHEMA,
+				seq.name AS SEQUENCE_NAME,
+				TYPE_NAME(seq.user_type_id) AS DATA_TYPE,
+				CAST(seq.start_value AS bigint) AS START_VALUE,
+				CAST(seq.increment AS bigint) AS INCREMENT,
+				CASE WHEN seq.system_type_id IN (48, 52, 56, 127) THEN CAST(seq.minimum_value AS bigint) END AS MINIMUM_VALUE,
+				CASE WHEN seq.system_type_id IN (48, 52, 56, 127) THEN CAST(seq.maximum_value AS bigint) END AS MAXIMUM_VALUE,
+				CAST(seq.is_cycling AS int) AS IS_CYCLING,
+				CAST(seq.current_value AS bigint) AS CURRENT_VALUE,
+				'' AS OWNED_BY
+			FROM sys.sequences seq
+			JOIN sys.schemas s ON s.schema_id = seq.schema_id
+			WHERE s.name = @schema
+			ORDER BY seq.name
+		`
+	case "postgres":
+		// deptype 'a' es OWNED BY de una serial e 'i' la secuencia interna de
+		// una columna identity
+		return `
+			SELECT
+				s.schemaname,
+				s.sequencename,
+				format_type(s.data_type, NULL),
+				s.start_value,
+				s.increment_by,
+				s.min_value,
+				s.max_value,
+				CASE WHEN s.cycle THEN 1 ELSE 0 END,
+				s.last_value,
+				COALESCE((
+					SELECT t.relname || '.' || a.attname
+					FROM pg_depend d
+					JOIN pg_class t ON t.oid = d.refobjid
+					JOIN pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+					WHERE d.classid = 'pg_class'::regclass
+					AND d.refclassid = 'pg_class'::regclass
+					AND d.objid = format('%I.%I', s.schemaname, s.sequencename)::regclass
+					AND d.deptype IN ('a', 'i')
+					LIMIT 1
+				), '')
+			FROM pg_sequences s
+			WHERE s.schemaname = $1
+			ORDER BY s.sequencename
+		`
+	default:
+		return ""
+	}
+}
+
+func nullInt64Pointer(value sql.NullInt64) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	v := value.Int64
+	return &v
+}
+
+// Genera CREATE SEQUENCE; la sintaxis es la misma en PostgreSQL y SQL Server
+func generateCreateSequence(seq Sequence, dialect string) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "CREATE SEQUENCE %s", quoteTableName(seq.Schema, seq.SequenceName, dialect))
+	if seq.DataType != "" {
+		sb.WriteString(" AS " + seq.DataType)
+	}
+	fmt.Fprintf(&sb, " START WITH %d INCREMENT BY %d", seq.StartValue, seq.Increment)
+	if seq.MinValue != nil {
+		fmt.Fprintf(&sb, " MINVALUE %d", *seq.MinValue)
+	}
+	if seq.MaxValue != nil {
+		fmt.Fprintf(&sb, " MAXVALUE %d", *seq.MaxValue)
+	}
+	if seq.Cycle {
+		sb.WriteString(" CYCLE")
+	} else {
+		sb.WriteString(" NO CYCLE")
+	}
+
+	return sb.String()
+}
+
+// Indica si el dialecto admite CREATE SEQUENCE
+func supportsSequences(dialect string) bool {
+	return dialect == "postgres" || dialect == "sqlserver"
+}
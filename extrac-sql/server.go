@@ -0,0 +1,313 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Bases de datos de sistema que no se extraen en el modo de servidor
+var systemDatabases = map[string][]string{
+	"sqlserver": {"master", "tempdb", "model", "msdb"},
+	"sybase":    {"master", "model", "tempdb", "sybsystemprocs", "sybsystemdb", "sybsecurity", "sybmgmtdb", "dbccdb"},
+	"mysql":     {"information_schema", "mysql", "performance_schema", "sys"},
+	"mongodb":   {"admin", "local", "config"},
+}
+
+// Catálogo con los esquemas de todas las bases de un servidor (-combined)
+type ServerCatalog struct {
+	Server         string            `json:"server"`
+	DBType         string            `json:"dbType"`
+	Databases      []*DatabaseSchema `json:"databases,omitempty"`
+	MongoDatabases []*MongoSchema    `json:"mongoDatabases,omitempty"`
+	Errors         []DatabaseError   `json:"errors,omitempty"`
+}
+
+// Base de datos que no se pudo extraer
+type DatabaseError struct {
+	Database string `json:"database"`
+	Error    string `json:"error"`
+}
+
+// Indica si el motor admite el modo de servidor (-alldatabases)
+func supportsServerMode(dbType string) bool {
+	switch dbType {
+	case "sqlserver", "sybase", "mysql", "postgres", "mongodb":
+		return true
+	}
+	return false
+}
+
+// Base a la que conectarse para listar las demás cuando no se indica -database
+func serverDefaultDatabase(dbType string) string {
+	switch dbType {
+	case "sqlserver", "sybase":
+		return "master"
+	case "postgres":
+		return "postgres"
+	case "mongodb":
+		return "admin"
+	default:
+		return ""
+	}
+}
+
+// Extrae todas las bases del servidor que pasan los filtros -dbinclude y
+// -dbexclude, en un archivo por base o en un catálogo combinado. Si alguna
+// base falla devuelve un error después de guardar las demás.
+func processServer(config Config) error {
+	var client *mongo.Client
+	var names []string
+	var err error
+
+	if config.DBType == "mongodb" {
+		client, err = connectMongoDB(config)
+		if err != nil {
+			return fmt.Errorf("error al conectar a MongoDB: %v", err)
+		}
+		defer client.Disconnect(nil)
+
+		names, err = client.ListDatabaseNames(nil, bson.D{})
+	} else {
+		names, err = listSQLDatabases(config)
+	}
+	if err != nil {
+		return fmt.Errorf("error al listar las bases de datos: %v", err)
+	}
+
+	names = filterDatabases(config.DBType, names, config.DatabaseInclude, config.DatabaseExclude)
+	if len(names) == 0 {
+		return fmt.Errorf("ninguna base de datos coincide con los filtros")
+	}
+
+	fmt.Printf("🗄️  Bases de datos a extraer: %d (%s)\n", len(names), strings.Join(names, ", "))
+
+	catalog := &ServerCatalog{Server: config.Server, DBType: config.DBType}
+
+	for _, name := range names {
+		fmt.Printf("\n📦 Base de datos: %s\n", name)
+
+		dbConfig := databaseConfig(config, name)
+		if config.DBType == "mongodb" {
+			err = extractServerMongoDatabase(client, dbConfig, catalog)
+		} else {
+			err = extractServerSQLDatabase(dbConfig, catalog)
+		}
+
+		if err != nil {
+			fmt.Printf("  ⚠️  No se pudo extraer %s: %v\n", name, err)
+			catalog.Errors = append(catalog.Errors, DatabaseError{Database: name, Error: err.Error()})
+		}
+	}
+
+	if config.Combined {
+		if err := saveToJSONFile(catalog, config.Output); err != nil {
+			return fmt.Errorf("error al guardar el catálogo: %v", err)
+		}
+		fmt.Printf("\n✅ Catálogo guardado en: %s\n", config.Output)
+	}
+
+	fmt.Printf("📊 Bases de datos extraídas: %d de %d\n", len(names)-len(catalog.Errors), len(names))
+
+	if len(catalog.Errors) > 0 {
+		return fmt.Errorf("%d bases de datos no se pudieron extraer", len(catalog.Errors))
+	}
+	return nil
+}
+
+func extractServerSQLDatabase(config Config, catalog *ServerCatalog) error {
+	schema, err := extractLiveSchema(config)
+	if err != nil {
+		return err
+	}
+
+	schema, err = applyConversion(schema, config)
+	if err != nil {
+		return err
+	}
+
+	if config.Combined {
+		catalog.Databases = append(catalog.Databases, schema)
+	} else {
+		if err := saveSchemaOutput(schema, config); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Esquema guardado en: %s (%d tablas)\n", config.Output, len(schema.Tables))
+	}
+
+	return incompleteExtractionError(schema.Errors, "objetos")
+}
+
+func extractServerMongoDatabase(client *mongo.Client, config Config, catalog *ServerCatalog) error {
+	schema, err := extractMongoDBSchema(client, config)
+	if err != nil {
+		return err
+	}
+
+	if config.Combined {
+		catalog.MongoDatabases = append(catalog.MongoDatabases, schema)
+	} else {
+		if err := saveToJSONFile(schema, config.Output); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Esquema guardado en: %s (%d colecciones)\n", config.Output, len(schema.Collections))
+	}
+
+	return incompleteExtractionError(schema.Errors, "colecciones")
+}
+
+// La base se guarda sin los objetos que fallaron (detallados en su campo
+// errors); se informa como error para que el programa termine con 1
+func incompleteExtractionError(failed []ObjectError, kind string) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("extracción incompleta, %d %s no se pudieron extraer", len(failed), kind)
+}
+
+// Lista las bases del servidor conectándose a la base indicada con -database
+func listSQLDatabases(config Config) ([]string, error) {
+	query := getDatabasesQuery(config.DBType)
+	if query == "" {
+		return nil, fmt.Errorf("el modo de servidor no está disponible para %s", config.DBType)
+	}
+
+	db, err := sql.Open(getDriverName(config.DBType), getConnectionString(config))
+	if err != nil {
+		return nil, fmt.Errorf("error al conectar a la base de datos: %v", err)
+	}
+	defer db.Close()
+
+	if err = db.Ping(); err != nil {
+		return nil, fmt.Errorf("error al verificar la conexión: %v", err)
+	}
+
+	fmt.Printf("✅ Conexión exitosa a %s\n", strings.ToUpper(config.DBType))
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar bases de datos: %v", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("error al escanear base de datos: %v", err)
+		}
+		names = append(names, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre bases de datos: %v", err)
+	}
+
+	return names, nil
+}
+
+func getDatabasesQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		// Las bases fuera de línea o en restauración no admiten conexiones
+		return `
+			SELECT name
+			FROM sys.databases
+			WHERE state_desc = 'ONLINE'
+			ORDER BY name
+		`
+	case "sybase":
+		return `
+			SELECT name
+			FROM master..sysdatabases
+			ORDER BY name
+		`
+	case "mysql":
+		return `SHOW DATABASES`
+	case "postgres":
+		return `
+			SELECT datname
+			FROM pg_database
+			WHERE NOT datistemplate
+			AND datallowconn
+			ORDER BY datname
+		`
+	default:
+		return ""
+	}
+}
+
+// Descarta las bases de sistema y aplica los patrones de inclusión y
+// exclusión (glob, sin distinguir mayúsculas)
+func filterDatabases(dbType string, names []string, include, exclude string) []string {
+	includePatterns := splitNameList(include)
+	excludePatterns := splitNameList(exclude)
+
+	var filtered []string
+	for _, name := range names {
+		if isSystemDatabase(dbType, name) {
+			continue
+		}
+		if len(includePatterns) > 0 && !matchesAnyPattern(includePatterns, name) {
+			continue
+		}
+		if matchesAnyPattern(excludePatterns, name) {
+			continue
+		}
+		filtered = append(filtered, name)
+	}
+
+	sort.Strings(filtered)
+	return filtered
+}
+
+func isSystemDatabase(dbType, name string) bool {
+	for _, system := range systemDatabases[dbType] {
+		if strings.EqualFold(system, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Configuración para extraer una base del servidor. Los archivos de salida
+// e informe llevan el nombre de la base: esquema.json -> esquema_ventas.json
+func databaseConfig(config Config, name string) Config {
+	dbConfig := config
+	dbConfig.Database = name
+
+	// En MySQL el schema es la base de datos
+	if config.DBType == "mysql" {
+		dbConfig.Schema = name
+	}
+
+	if !config.Combined {
+		dbConfig.Output = perDatabasePath(config.Output, name)
+	}
+	if config.Report != "" {
+		dbConfig.Report = perDatabasePath(config.Report, name)
+	}
+
+	return dbConfig
+}
+
+func perDatabasePath(path, database string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "_" + sanitizeFileName(database) + ext
+}
+
+// Reemplaza los caracteres que no son válidos en un nombre de archivo
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, name)
+}
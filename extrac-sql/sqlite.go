@@ -0,0 +1,313 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SQLite no tiene schemas de usuario; todas las tablas viven en "main"
+const sqliteSchema = "main"
+
+// Extrae longitud, precisión y escala del tipo declarado, p. ej. VARCHAR(20)
+// o DECIMAL(10,2). SQLite no valida estos valores pero se conservan tal cual.
+var sqliteTypeArgs = regexp.MustCompile(`^\s*([^(]*?)\s*\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)`)
+
+var sqliteWhereClause = regexp.MustCompile(`(?i)\sWHERE\s`)
+
+// Función específica para extraer columnas de SQLite con pragma_table_info.
+// withoutRowID es la opción de la tabla leída con getSQLiteTableOptions.
+func extractSQLiteTableColumns(db *sql.DB, tableName string, withoutRowID bool) ([]Column, error) {
+	query := `
+		SELECT
+			name,
+			type,
+			"notnull",
+			COALESCE(dflt_value, ''),
+			pk
+		FROM pragma_table_info(?)
+		ORDER BY cid
+	`
+
+	rowsColumns, err := db.Query(query, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar columnas: %v", err)
+	}
+	defer rowsColumns.Close()
+
+	var columns []Column
+	var primaryKeyCount int
+
+	for rowsColumns.Next() {
+		var col Column
+		var declaredType string
+		var notNull, pk int
+
+		err := rowsColumns.Scan(&col.ColumnName, &declaredType, &notNull, &col.DefaultValue, &pk)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear columna: %v", err)
+		}
+
+		col.DataType, col.MaxLength, col.Precision, col.Scale = parseSQLiteType(declaredType)
+		col.IsPrimaryKey = pk > 0
+		if col.IsPrimaryKey {
+			primaryKeyCount++
+		}
+
+		// Las claves primarias de tablas con rowid admiten NULL salvo que
+		// se declare NOT NULL, igual que informa pragma_table_info
+		col.IsNullable = "YES"
+		if notNull == 1 {
+			col.IsNullable = "NO"
+		}
+
+		columns = append(columns, col)
+	}
+
+	if err = rowsColumns.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre columnas: %v", err)
+	}
+
+	// Una columna INTEGER PRIMARY KEY única es un alias del rowid y el
+	// motor le asigna valores automáticamente
+	if primaryKeyCount == 1 && !withoutRowID {
+		for i, col := range columns {
+			if col.IsPrimaryKey && col.DataType == "integer" {
+				columns[i].IsIdentity = true
+			}
+		}
+	}
+
+	return columns, nil
+}
+
+// Separa el tipo declarado en nombre, longitud, precisión y escala
+func parseSQLiteType(declaredType string) (string, int, int, int) {
+	match := sqliteTypeArgs.FindStringSubmatch(declaredType)
+	if match == nil {
+		return strings.ToLower(strings.TrimSpace(declaredType)), 0, 0, 0
+	}
+
+	dataType := strings.ToLower(match[1])
+	first, _ := strconv.Atoi(match[2])
+
+	if match[3] != "" {
+		scale, _ := strconv.Atoi(match[3])
+		return dataType, 0, first, scale
+	}
+
+	if strings.Contains(dataType, "char") || strings.Contains(dataType, "text") ||
+		strings.Contains(dataType, "clob") || strings.Contains(dataType, "binary") {
+		return dataType, first, 0, 0
+	}
+
+	return dataType, 0, first, 0
+}
+
+// Lee las opciones WITHOUT ROWID y STRICT de la sentencia CREATE TABLE
+// almacenada en sqlite_master (aparecen después del último paréntesis)
+func getSQLiteTableOptions(db *sql.DB, tableName string) (bool, bool, error) {
+	var createSQL string
+
+	err := db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?`, tableName).Scan(&createSQL)
+	if err == sql.ErrNoRows {
+		// Las vistas no tienen opciones de tabla
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("error al consultar definición de tabla: %v", err)
+	}
+
+	options := strings.ToUpper(createSQL[strings.LastIndex(createSQL, ")")+1:])
+	withoutRowID := strings.Contains(strings.Join(strings.Fields(options), " "), "WITHOUT ROWID")
+	strict := strings.Contains(options, "STRICT")
+
+	return withoutRowID, strict, nil
+}
+
+// Función específica para extraer claves foráneas de SQLite.
+// SQLite no guarda el nombre de las restricciones, así que se genera uno
+// a partir del identificador que asigna pragma_foreign_key_list.
+func extractSQLiteForeignKeys(db *sql.DB, tableName string) ([]ForeignKey, error) {
+	query := `
+		SELECT
+			id,
+			"table",
+			"from",
+			"to",
+			on_delete,
+			on_update
+		FROM pragma_foreign_key_list(?)
+		ORDER BY id, seq
+	`
+
+	rows, err := db.Query(query, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar claves foráneas: %v", err)
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKey
+
+	for rows.Next() {
+		var id int
+		var refTable, columnName, onDelete, onUpdate string
+		var refColumn sql.NullString
+
+		err := rows.Scan(&id, &refTable, &columnName, &refColumn, &onDelete, &onUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear clave foránea: %v", err)
+		}
+
+		constraintName := fmt.Sprintf("fk_%s_%d", tableName, id)
+		foreignKeys = appendForeignKeyColumn(foreignKeys, constraintName, columnName,
+			sqliteSchema, refTable, refColumn.String, onDelete, onUpdate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre claves foráneas: %v", err)
+	}
+
+	// Las referencias sin columnas (REFERENCES tabla) apuntan a la clave
+	// primaria de la tabla referenciada
+	for i, fk := range foreignKeys {
+		if fk.ReferencedColumns[0] != "" {
+			continue
+		}
+		primaryKey, err := getSQLitePrimaryKeyColumns(db, fk.ReferencedTable)
+		if err != nil {
+			return nil, err
+		}
+		if len(primaryKey) == len(fk.Columns) {
+			foreignKeys[i].ReferencedColumns = primaryKey
+		}
+	}
+
+	return foreignKeys, nil
+}
+
+func getSQLitePrimaryKeyColumns(db *sql.DB, tableName string) ([]string, error) {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info(?) WHERE pk > 0 ORDER BY pk`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar clave primaria: %v", err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns = append(columns, name)
+	}
+
+	return columns, rows.Err()
+}
+
+// Función específica para extraer índices de SQLite con pragma_index_list y
+// pragma_index_xinfo. Las claves primarias INTEGER de tablas con rowid no
+// tienen índice propio y por lo tanto no aparecen.
+func extractSQLiteIndexes(db *sql.DB, tableName string, withoutRowID bool) ([]Index, error) {
+	query := `
+		SELECT
+			il.name,
+			il."unique",
+			il.origin,
+			COALESCE(m.sql, '')
+		FROM pragma_index_list(?) il
+		LEFT JOIN sqlite_master m ON m.type = 'index' AND m.name = il.name
+		ORDER BY il.name
+	`
+
+	rows, err := db.Query(query, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar índices: %v", err)
+	}
+
+	var indexes []Index
+
+	for rows.Next() {
+		var index Index
+		var unique int
+		var origin, createSQL string
+
+		if err := rows.Scan(&index.IndexName, &unique, &origin, &createSQL); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error al escanear índice: %v", err)
+		}
+
+		index.Columns = []IndexColumn{}
+		index.IsUnique = unique == 1
+		index.IsPrimaryKey = origin == "pk"
+		index.IsUniqueConstraint = origin == "u"
+		index.IsClustered = index.IsPrimaryKey && withoutRowID
+		index.IndexType = "BTREE"
+		index.FilterPredicate = parseSQLiteIndexPredicate(createSQL)
+
+		indexes = append(indexes, index)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre índices: %v", err)
+	}
+
+	// Las columnas se consultan después de cerrar el cursor de índices
+	for i := range indexes {
+		columns, err := getSQLiteIndexColumns(db, indexes[i].IndexName)
+		if err != nil {
+			return nil, err
+		}
+		indexes[i].Columns = columns
+	}
+
+	return indexes, nil
+}
+
+func getSQLiteIndexColumns(db *sql.DB, indexName string) ([]IndexColumn, error) {
+	// cid = -2 indica una expresión; key = 0 son columnas auxiliares (rowid)
+	query := `
+		SELECT
+			COALESCE(name, '(expresión)'),
+			"desc"
+		FROM pragma_index_xinfo(?)
+		WHERE key = 1
+		ORDER BY seqno
+	`
+
+	rows, err := db.Query(query, indexName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar columnas del índice %s: %v", indexName, err)
+	}
+	defer rows.Close()
+
+	columns := []IndexColumn{}
+	for rows.Next() {
+		var name string
+		var desc int
+		if err := rows.Scan(&name, &desc); err != nil {
+			return nil, fmt.Errorf("error al escanear columna de índice: %v", err)
+		}
+
+		order := "ASC"
+		if desc == 1 {
+			order = "DESC"
+		}
+		columns = append(columns, IndexColumn{ColumnName: name, Order: order})
+	}
+
+	return columns, rows.Err()
+}
+
+// Los índices parciales guardan su condición en la cláusula WHERE del
+// CREATE INDEX original
+func parseSQLiteIndexPredicate(createSQL string) string {
+	matches := sqliteWhereClause.FindAllStringIndex(createSQL, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(createSQL[matches[len(matches)-1][1]:])
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestParseSQLiteType(t *testing.T) {
+	tests := []struct {
+		declared                    string
+		dataType                    string
+		maxLength, precision, scale int
+	}{
+		{"INTEGER", "integer", 0, 0, 0},
+		{"VARCHAR(100)", "varchar", 100, 0, 0},
+		{"NVARCHAR (20)", "nvarchar", 20, 0, 0},
+		{"DECIMAL(12,2)", "decimal", 0, 12, 2},
+		{"DECIMAL(10, 4)", "decimal", 0, 10, 4},
+		{"NUMERIC(8)", "numeric", 0, 8, 0},
+		{"", "", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		dataType, maxLength, precision, scale := parseSQLiteType(tt.declared)
+		if dataType != tt.dataType || maxLength != tt.maxLength || precision != tt.precision || scale != tt.scale {
+			t.Errorf("parseSQLiteType(%q) = %q, %d, %d, %d; se esperaba %q, %d, %d, %d", tt.declared,
+				dataType, maxLength, precision, scale, tt.dataType, tt.maxLength, tt.precision, tt.scale)
+		}
+	}
+}
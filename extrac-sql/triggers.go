@@ -0,0 +1,314 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Momento y evento en la sentencia CREATE TRIGGER de SQLite; sin momento
+// explícito el trigger es BEFORE
+var sqliteTriggerClause = regexp.MustCompile(`(?is)\b(?:(BEFORE|AFTER|INSTEAD\s+OF)\s+)?(DELETE|INSERT|UPDATE)\b(?:\s+OF\s+.+?)?\s+ON\s`)
+
+// Estructura para almacenar la información de un trigger. Timing es BEFORE,
+// AFTER o INSTEAD OF (COMPOUND en Oracle) y Events contiene INSERT, UPDATE,
+// DELETE o TRUNCATE. Definition es el texto tal como lo guarda el motor.
+type Trigger struct {
+	TriggerName string   `json:"triggerName"`
+	Timing      string   `json:"timing"`
+	Events      []string `json:"events"`
+	Enabled     bool     `json:"enabled"`
+	Definition  string   `json:"definition"`
+}
+
+func extractTriggers(db *sql.DB, dbType, schemaName, tableName string) ([]Trigger, error) {
+	// Para Sybase, construimos la consulta dinámicamente sin parámetros
+	if dbType == "sybase" {
+		return extractSybaseTriggers(db, schemaName, tableName)
+	}
+
+	if dbType == "sqlite" {
+		return extractSQLiteTriggers(db, tableName)
+	}
+
+	query := getTriggersQuery(dbType)
+	var rows *sql.Rows
+	var err error
+
+	switch dbType {
+	case "sqlserver":
+		rows, err = db.Query(query, sql.Named("schema", schemaName), sql.Named("table", tableName))
+	case "mysql", "postgres", "oracle":
+		rows, err = db.Query(query, schemaName, tableName)
+	default:
+		return nil, fmt.Errorf("tipo de base de datos no soportado: %s", dbType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar triggers: %v", err)
+	}
+	defer rows.Close()
+
+	var triggers []Trigger
+	for rows.Next() {
+		var name, timing, events string
+		var enabled int
+		var definition sql.NullString
+
+		err := rows.Scan(&name, &timing, &events, &enabled, &definition)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear trigger: %v", err)
+		}
+
+		triggers = appendTriggerEvents(triggers, Trigger{
+			TriggerName: name,
+			Timing:      timing,
+			Enabled:     enabled == 1,
+			Definition:  strings.TrimSpace(definition.String),
+		}, events)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre triggers: %v", err)
+	}
+
+	return triggers, nil
+}
+
+// Todas las consultas devuelven nombre, momento, eventos separados por coma,
+// 1 si está habilitado y definición. SQL Server devuelve una fila por evento.
+func getTriggersQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				tr.name AS TRIGGER_NAME,
+				CASE WHEN tr.is_instead_of_trigger = 1 THEN 'INSTEAD OF' ELSE 'AFTER' END AS TIMING,
+				te.type_desc AS EVENT,
+				CASE WHEN tr.is_disabled = 1 THEN 0 ELSE 1 END AS IS_ENABLED,
+				m.definition AS DEFINITION
+			FROM sys.triggers tr
+			JOIN sys.trigger_events te ON te.object_id = tr.object_id
+			LEFT JOIN sys.sql_modules m ON m.object_id = tr.object_id
+			WHERE tr.parent_id = OBJECT_ID(QUOTENAME(@schema) + '.' + QUOTENAME(@table))
+			ORDER BY tr.name, te.type
+		`
+	case "mysql":
+		// MySQL no permite deshabilitar triggers
+		return `
+			SELECT
+				TRIGGER_NAME,
+				ACTION_TIMING,
+				EVENT_MANIPULATION,
+				1 AS IS_ENABLED,
+				ACTION_STATEMENT
+			FROM INFORMATION_SCHEMA.TRIGGERS
+			WHERE EVENT_OBJECT_SCHEMA = ?
+			AND EVENT_OBJECT_TABLE = ?
+			ORDER BY ACTION_ORDER, TRIGGER_NAME
+		`
+	case "postgres":
+		// Bits de tgtype: 2 BEFORE, 4 INSERT, 8 DELETE, 16 UPDATE,
+		// 32 TRUNCATE, 64 INSTEAD OF
+		return `
+			SELECT
+				t.tgname,
+				CASE
+					WHEN t.tgtype & 2 = 2 THEN 'BEFORE'
+					WHEN t.tgtype & 64 = 64 THEN 'INSTEAD OF'
+					ELSE 'AFTER'
+				END,
+				concat_ws(',',
+					CASE WHEN t.tgtype & 4 = 4 THEN 'INSERT' END,
+					CASE WHEN t.tgtype & 16 = 16 THEN 'UPDATE' END,
+					CASE WHEN t.tgtype & 8 = 8 THEN 'DELETE' END,
+					CASE WHEN t.tgtype & 32 = 32 THEN 'TRUNCATE' END),
+				CASE WHEN t.tgenabled = 'D' THEN 0 ELSE 1 END,
+				pg_get_triggerdef(t.oid, true)
+			FROM pg_trigger t
+			WHERE t.tgrelid = format('%I.%I', $1::text, $2::text)::regclass
+			AND NOT t.tgisinternal
+			ORDER BY t.tgname
+		`
+	case "oracle":
+		// TRIGGER_TYPE es por ejemplo BEFORE EACH ROW o AFTER STATEMENT y
+		// TRIGGERING_EVENT une los eventos con OR
+		return `
+			SELECT
+				trigger_name,
+				CASE
+					WHEN trigger_type LIKE 'BEFORE%' THEN 'BEFORE'
+					WHEN trigger_type LIKE 'AFTER%' THEN 'AFTER'
+					WHEN trigger_type LIKE 'INSTEAD OF%' THEN 'INSTEAD OF'
+					ELSE trigger_type
+				END AS timing,
+				REPLACE(triggering_event, ' OR ', ',') AS events,
+				CASE WHEN status = 'ENABLED' THEN 1 ELSE 0 END AS is_enabled,
+				trigger_body
+			FROM all_triggers
+			WHERE table_owner = :1
+			AND table_name = :2
+			AND base_object_type = 'TABLE'
+			ORDER BY trigger_name
+		`
+	default:
+		return ""
+	}
+}
+
+// Agrega los eventos (separados por coma) al trigger anterior si tiene el
+// mismo nombre, o agrega un trigger nuevo
+func appendTriggerEvents(triggers []Trigger, trigger Trigger, events string) []Trigger {
+	var parsed []string
+	for _, event := range strings.Split(events, ",") {
+		if event = strings.ToUpper(strings.TrimSpace(event)); event != "" {
+			parsed = append(parsed, event)
+		}
+	}
+
+	if n := len(triggers); n > 0 && triggers[n-1].TriggerName == trigger.TriggerName {
+		triggers[n-1].Events = append(triggers[n-1].Events, parsed...)
+		return triggers
+	}
+
+	trigger.Events = parsed
+	return append(triggers, trigger)
+}
+
+// Función específica para extraer triggers de Sybase (sin parámetros). La
+// tabla guarda el trigger de cada evento en instrig, updtrig y deltrig, y los
+// bits de sysstat2 indican si están deshabilitados. Los triggers de Sybase
+// siempre se ejecutan después de la sentencia.
+func extractSybaseTriggers(db *sql.DB, schemaName, tableName string) ([]Trigger, error) {
+	var parts []string
+	for _, event := range []struct {
+		name, column string
+		disabledBit  int
+	}{
+		{"INSERT", "instrig", 1048576},
+		{"UPDATE", "updtrig", 4194304},
+		{"DELETE", "deltrig", 2097152},
+	} {
+		parts = append(parts, fmt.Sprintf(`
+		SELECT
+			tr.name as trigger_name,
+			'%s' as event,
+			CASE WHEN t.sysstat2 & %d = %d THEN 0 ELSE 1 END as is_enabled
+		FROM sysobjects t
+		JOIN sysobjects tr ON tr.id = t.%s
+		WHERE t.id = object_id('%s.%s')`, event.name, event.disabledBit, event.disabledBit, event.column, schemaName, tableName))
+	}
+	query := strings.Join(parts, "\n\t\tUNION ALL") + "\n\t\tORDER BY 1\n"
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar triggers: %v", err)
+	}
+	defer rows.Close()
+
+	var triggers []Trigger
+	for rows.Next() {
+		var name, event string
+		var enabled int
+
+		err := rows.Scan(&name, &event, &enabled)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear trigger: %v", err)
+		}
+
+		triggers = appendTriggerEvents(triggers, Trigger{TriggerName: name, Timing: "AFTER", Enabled: enabled == 1}, event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre triggers: %v", err)
+	}
+	rows.Close()
+
+	for i := range triggers {
+		triggers[i].Definition, err = getSybaseObjectText(db, schemaName, triggers[i].TriggerName)
+		if err != nil {
+			return nil, fmt.Errorf("error al extraer el texto del trigger %s: %v", triggers[i].TriggerName, err)
+		}
+	}
+
+	return triggers, nil
+}
+
+// Devuelve el texto de un objeto de Sybase, guardado en syscomments en
+// fragmentos ordenados por colid
+func getSybaseObjectText(db *sql.DB, schemaName, objectName string) (string, error) {
+	return querySybaseComments(db, fmt.Sprintf("object_id('%s.%s')", schemaName, objectName))
+}
+
+// Une los fragmentos de syscomments del objeto cuyo id devuelve la expresión
+func querySybaseComments(db *sql.DB, objectID string) (string, error) {
+	query := fmt.Sprintf(`
+		SELECT text
+		FROM syscomments
+		WHERE id = %s
+		ORDER BY colid
+	`, objectID)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return "", fmt.Errorf("error al consultar syscomments: %v", err)
+	}
+	defer rows.Close()
+
+	var sb strings.Builder
+	for rows.Next() {
+		var text sql.NullString
+		if err := rows.Scan(&text); err != nil {
+			return "", fmt.Errorf("error al escanear syscomments: %v", err)
+		}
+		sb.WriteString(text.String)
+	}
+
+	if err = rows.Err(); err != nil {
+		return "", fmt.Errorf("error iterando sobre syscomments: %v", err)
+	}
+
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// Función específica para extraer triggers de SQLite. El momento y el evento
+// se leen de la sentencia CREATE TRIGGER guardada en sqlite_master.
+func extractSQLiteTriggers(db *sql.DB, tableName string) ([]Trigger, error) {
+	rows, err := db.Query(`
+		SELECT name, sql
+		FROM sqlite_master
+		WHERE type = 'trigger'
+		AND tbl_name = ?
+		ORDER BY name
+	`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar triggers: %v", err)
+	}
+	defer rows.Close()
+
+	var triggers []Trigger
+	for rows.Next() {
+		var trigger Trigger
+		err := rows.Scan(&trigger.TriggerName, &trigger.Definition)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear trigger: %v", err)
+		}
+
+		trigger.Timing = "BEFORE"
+		trigger.Enabled = true
+		if match := sqliteTriggerClause.FindStringSubmatch(trigger.Definition); match != nil {
+			if match[1] != "" {
+				trigger.Timing = strings.ToUpper(strings.Join(strings.Fields(match[1]), " "))
+			}
+			trigger.Events = []string{strings.ToUpper(match[2])}
+		}
+
+		triggers = append(triggers, trigger)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre triggers: %v", err)
+	}
+
+	return triggers, nil
+}
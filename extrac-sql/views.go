@@ -0,0 +1,300 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Estructura para almacenar la información de una vista. Definition es el
+// texto tal como lo guarda el motor: la sentencia CREATE VIEW completa en SQL
+// Server, Sybase y SQLite, y solo la consulta en MySQL, PostgreSQL y Oracle.
+type View struct {
+	ViewName   string   `json:"viewName"`
+	Schema     string   `json:"schema"`
+	Columns    []Column `json:"columns"`
+	Definition string   `json:"definition"`
+	// Vistas materializadas de PostgreSQL y Oracle
+	Materialized bool `json:"materialized,omitempty"`
+}
+
+func extractViews(db *sql.DB, dbType, schemaName string) ([]View, error) {
+	// Para Sybase, construimos la consulta dinámicamente sin parámetros
+	if dbType == "sybase" {
+		return extractSybaseViews(db, schemaName)
+	}
+
+	if dbType == "oracle" {
+		return extractOracleViews(db, schemaName)
+	}
+
+	query := getViewsQuery(dbType)
+	var rows *sql.Rows
+	var err error
+
+	switch dbType {
+	case "sqlserver":
+		rows, err = db.Query(query, sql.Named("schema", schemaName))
+	case "mysql", "postgres":
+		rows, err = db.Query(query, schemaName)
+	case "sqlite":
+		rows, err = db.Query(query)
+	default:
+		return nil, fmt.Errorf("tipo de base de datos no soportado: %s", dbType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar vistas: %v", err)
+	}
+
+	views, err := scanViews(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return addViewColumns(db, dbType, views)
+}
+
+// Lee filas con schema, nombre, definición y 1 si la vista es materializada,
+// y cierra el cursor
+func scanViews(rows *sql.Rows) ([]View, error) {
+	defer rows.Close()
+
+	var views []View
+	for rows.Next() {
+		var view View
+		var definition sql.NullString
+		var materialized int
+
+		err := rows.Scan(&view.Schema, &view.ViewName, &definition, &materialized)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear vista: %v", err)
+		}
+
+		// SQL Server devuelve NULL para las vistas creadas WITH ENCRYPTION
+		view.Definition = strings.TrimSpace(definition.String)
+		view.Materialized = (materialized == 1)
+		views = append(views, view)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre vistas: %v", err)
+	}
+
+	return views, nil
+}
+
+// Las columnas se consultan después de cerrar el cursor de vistas, porque
+// algunos drivers no admiten dos consultas abiertas en la misma conexión
+func addViewColumns(db *sql.DB, dbType string, views []View) ([]View, error) {
+	var err error
+	for i := range views {
+		views[i].Columns, err = extractViewColumns(db, dbType, views[i])
+		if err != nil {
+			return nil, fmt.Errorf("error al extraer columnas para vista %s: %v", views[i].ViewName, err)
+		}
+	}
+	return views, nil
+}
+
+// Todas las consultas devuelven schema, nombre, definición y 1 si la vista
+// es materializada. Oracle se consulta aparte porque no admite columnas LONG
+// en un UNION.
+func getViewsQuery(dbType string) string {
+	switch dbType {
+	case "sqlserver":
+		return `
+			SELECT
+				s.name AS VIEW_SCHEMA,
+				v.name AS VIEW_NAME,
+				m.definition AS VIEW_DEFINITION,
+				0 AS IS_MATERIALIZED
+			FROM sys.views v
+			JOIN sys.schemas s ON s.schema_id = v.schema_id
+			LEFT JOIN sys.sql_modules m ON m.object_id = v.object_id
+			WHERE s.name = @schema
+			ORDER BY v.name
+		`
+	case "mysql":
+		return `
+			SELECT
+				TABLE_SCHEMA,
+				TABLE_NAME,
+				VIEW_DEFINITION,
+				0 AS IS_MATERIALIZED
+			FROM INFORMATION_SCHEMA.VIEWS
+			WHERE TABLE_SCHEMA = ?
+			ORDER BY TABLE_NAME
+		`
+	case "postgres":
+		return `
+			SELECT
+				schemaname,
+				viewname,
+				pg_get_viewdef(format('%I.%I', schemaname, viewname)::regclass, true),
+				0 AS is_materialized
+			FROM pg_views
+			WHERE schemaname = $1
+			UNION ALL
+			SELECT
+				schemaname,
+				matviewname,
+				pg_get_viewdef(format('%I.%I', schemaname, matviewname)::regclass, true),
+				1 AS is_materialized
+			FROM pg_matviews
+			WHERE schemaname = $1
+			ORDER BY 2
+		`
+	case "sqlite":
+		return `
+			SELECT
+				'main' AS view_schema,
+				name AS view_name,
+				sql AS view_definition,
+				0 AS is_materialized
+			FROM sqlite_master
+			WHERE type = 'view'
+			ORDER BY name
+		`
+	default:
+		return ""
+	}
+}
+
+// Función específica para extraer vistas de Sybase (sin parámetros). El
+// texto de la vista se guarda en syscomments en fragmentos ordenados por colid.
+func extractSybaseViews(db *sql.DB, schemaName string) ([]View, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			user_name(o.uid) as view_schema,
+			o.name as view_name,
+			c.text
+		FROM sysobjects o
+		JOIN syscomments c ON c.id = o.id
+		WHERE o.type = 'V'
+		AND user_name(o.uid) = '%s'
+		ORDER BY o.name, c.colid
+	`, schemaName)
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar vistas: %v", err)
+	}
+
+	defer rows.Close()
+
+	var views []View
+	for rows.Next() {
+		var viewSchema, viewName string
+		var text sql.NullString
+
+		err := rows.Scan(&viewSchema, &viewName, &text)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear vista: %v", err)
+		}
+
+		// Los fragmentos de una misma vista llegan consecutivos
+		if n := len(views); n > 0 && views[n-1].ViewName == viewName {
+			views[n-1].Definition += text.String
+			continue
+		}
+		views = append(views, View{ViewName: viewName, Schema: viewSchema, Definition: text.String})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre vistas: %v", err)
+	}
+	rows.Close()
+
+	for i := range views {
+		views[i].Definition = strings.TrimSpace(views[i].Definition)
+	}
+
+	return addViewColumns(db, "sybase", views)
+}
+
+func extractViewColumns(db *sql.DB, dbType string, view View) ([]Column, error) {
+	// information_schema.columns no incluye las vistas materializadas
+	if dbType == "postgres" && view.Materialized {
+		return extractPostgresMaterializedViewColumns(db, view.Schema, view.ViewName)
+	}
+
+	// Las vistas no tienen opciones de tabla
+	return extractTableColumns(db, dbType, view.Schema, view.ViewName, false)
+}
+
+// Usa las mismas funciones internas que information_schema para que los
+// tipos coincidan con los de las tablas
+func extractPostgresMaterializedViewColumns(db *sql.DB, schemaName, viewName string) ([]Column, error) {
+	query := `
+		SELECT
+			a.attname,
+			format_type(a.atttypid, NULL),
+			CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END,
+			information_schema._pg_char_max_length(a.atttypid, a.atttypmod),
+			information_schema._pg_numeric_precision(a.atttypid, a.atttypmod),
+			information_schema._pg_numeric_scale(a.atttypid, a.atttypmod),
+			information_schema._pg_datetime_precision(a.atttypid, a.atttypmod)
+		FROM pg_attribute a
+		WHERE a.attrelid = format('%I.%I', $1::text, $2::text)::regclass
+		AND a.attnum > 0
+		AND NOT a.attisdropped
+		ORDER BY a.attnum
+	`
+
+	rows, err := db.Query(query, schemaName, viewName)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar columnas: %v", err)
+	}
+	defer rows.Close()
+
+	var columns []Column
+	for rows.Next() {
+		var col Column
+		var charMaxLength, numericPrecision, numericScale, datetimePrecision sql.NullInt32
+
+		err := rows.Scan(&col.ColumnName, &col.DataType, &col.IsNullable, &charMaxLength, &numericPrecision, &numericScale, &datetimePrecision)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear columna: %v", err)
+		}
+
+		if charMaxLength.Valid {
+			col.MaxLength = int(charMaxLength.Int32)
+		}
+		if numericPrecision.Valid {
+			col.Precision = int(numericPrecision.Int32)
+		}
+		if numericScale.Valid {
+			col.Scale = int(numericScale.Int32)
+		}
+		if datetimePrecision.Valid {
+			setDatetimePrecision(&col, int(datetimePrecision.Int32))
+		}
+
+		columns = append(columns, col)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterando sobre columnas: %v", err)
+	}
+
+	return columns, nil
+}
+
+// Genera la sentencia CREATE VIEW. Si el motor guarda la sentencia completa
+// se usa tal cual; si solo guarda la consulta se antepone el encabezado.
+func generateCreateView(view View, dialect string) string {
+	definition := strings.TrimRight(strings.TrimSpace(view.Definition), ";")
+	if definition == "" {
+		return fmt.Sprintf("-- Vista %s sin definición disponible", qualifiedTableName(view.Schema, view.ViewName))
+	}
+
+	if strings.HasPrefix(strings.ToUpper(definition), "CREATE") {
+		return definition
+	}
+
+	keyword := "VIEW"
+	if view.Materialized {
+		keyword = "MATERIALIZED VIEW"
+	}
+	return fmt.Sprintf("CREATE %s %s AS\n%s", keyword, quoteTableName(view.Schema, view.ViewName, dialect), definition)
+}